@@ -34,24 +34,33 @@ func (o SrcMapping) IsYAMLMappingEqualTo(value string) bool {
 	return value == o[source.YAML]
 }
 
+// IsOSCALMappingEqualTo is a wrapper that checks if the input string equals to the OSCAL mapping.
+func (o SrcMapping) IsOSCALMappingEqualTo(value string) bool {
+	return value == o[source.OSCAL]
+}
+
 // GetSourceMappings returns a mapping of each implementation to their respective sources.
 func GetSourceMappings() map[Key]SrcMapping {
 	return map[Key]SrcMapping{
 		ImplementedImplementation: {
-			source.YAML: "complete",
-			source.SSP:  "Implemented",
+			source.YAML:  "complete",
+			source.OSCAL: "implemented",
+			source.SSP:   "Implemented",
 		},
 		PartialImplementation: {
-			source.YAML: "partial",
-			source.SSP:  "Partially implemented",
+			source.YAML:  "partial",
+			source.OSCAL: "partial",
+			source.SSP:   "Partially implemented",
 		},
 		PlannedImplementation: {
-			source.YAML: "planned",
-			source.SSP:  "Planned",
+			source.YAML:  "planned",
+			source.OSCAL: "planned",
+			source.SSP:   "Planned",
 		},
 		NotApplicableImplementation: {
-			source.YAML: "none",
-			source.SSP:  "Not applicable",
+			source.YAML:  "none",
+			source.OSCAL: "not-applicable",
+			source.SSP:   "Not applicable",
 		},
 		// For some reason the OpenControl Schema does not specify alternative implementation
 		//AlternativeImplementation: {
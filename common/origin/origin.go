@@ -37,36 +37,48 @@ func (o SrcMapping) IsYAMLMappingEqualTo(value string) bool {
 	return value == o[source.YAML]
 }
 
+// IsOSCALMappingEqualTo is a wrapper that checks if the input string equals to the OSCAL mapping.
+func (o SrcMapping) IsOSCALMappingEqualTo(value string) bool {
+	return value == o[source.OSCAL]
+}
+
 // GetSourceMappings returns a mapping of each control origination to their respective sources.
 func GetSourceMappings() map[Key]SrcMapping {
 	return map[Key]SrcMapping{
 		ServiceProviderCorporateOrigination: {
-			source.YAML: "service_provider_corporate",
-			source.SSP:  "Service Provider Corporate",
+			source.YAML:  "service_provider_corporate",
+			source.OSCAL: "sp-corporate",
+			source.SSP:   "Service Provider Corporate",
 		},
 		ServiceProviderSystemSpecificOrigination: {
-			source.YAML: "service_provided_system_specific",
-			source.SSP:  "Service Provider System Specific",
+			source.YAML:  "service_provided_system_specific",
+			source.OSCAL: "sp-system",
+			source.SSP:   "Service Provider System Specific",
 		},
 		ServiceProviderHybridOrigination: {
-			source.YAML: "hybrid",
-			source.SSP:  "Service Provider Hybrid",
+			source.YAML:  "hybrid",
+			source.OSCAL: "hybrid",
+			source.SSP:   "Service Provider Hybrid",
 		},
 		ConfiguredByCustomerOrigination: {
-			source.YAML: "customer_configured",
-			source.SSP:  "Configured by Customer",
+			source.YAML:  "customer_configured",
+			source.OSCAL: "customer-configured",
+			source.SSP:   "Configured by Customer",
 		},
 		ProvidedByCustomerOrigination: {
-			source.YAML: "customer_provided",
-			source.SSP:  "Provided by Customer",
+			source.YAML:  "customer_provided",
+			source.OSCAL: "customer-provided",
+			source.SSP:   "Provided by Customer",
 		},
 		SharedOrigination: {
-			source.YAML: "shared",
-			source.SSP:  "Shared",
+			source.YAML:  "shared",
+			source.OSCAL: "shared",
+			source.SSP:   "Shared",
 		},
 		InheritedOrigination: {
-			source.YAML: "inherited",
-			source.SSP:  "Inherited",
+			source.YAML:  "inherited",
+			source.OSCAL: "inherited",
+			source.SSP:   "Inherited",
 		},
 	}
 }
@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"gopkg.in/yaml.v2"
+)
+
+// definition is the content of a template profile file. The fields that are left out are the same as the ones of the
+// base profile.
+type definition struct {
+	Version              string `yaml:"version"`
+	Base                 string `yaml:"base"`
+	SummaryTablesXPath   string `yaml:"summary_tables_xpath"`
+	NarrativeTablesXPath string `yaml:"narrative_tables_xpath"`
+	ControlRegex         string `yaml:"control_regex"`
+	EnhancementFormat    string `yaml:"enhancement_format"`
+	PartRegex            string `yaml:"part_regex"`
+	Labels               Labels `yaml:"labels"`
+	// ControlOrigins and ImplementationStatuses map the OpenControl values (e.g. `shared`) to the text next to the
+	// checkbox.
+	ControlOrigins         map[string]string `yaml:"control_origins"`
+	ImplementationStatuses map[string]string `yaml:"implementation_statuses"`
+}
+
+// compileRegex compiles the regex of the field, checking that it has the expected number of submatches.
+func compileRegex(field, expr string, submatches int) (*regexp.Regexp, error) {
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", field, err)
+	}
+	if regex.NumSubexp() != submatches {
+		return nil, fmt.Errorf("%s must have %d submatches, found %d", field, submatches, regex.NumSubexp())
+	}
+	return regex, nil
+}
+
+// checkFormat checks that the format of the field has the expected number of `%s` verbs.
+func checkFormat(field, format string, verbs int) error {
+	if strings.Count(format, "%s") != verbs || strings.Count(format, "%") != verbs {
+		return fmt.Errorf("%s must have %d %%s, found %q", field, verbs, format)
+	}
+	return nil
+}
+
+// overrideString replaces the value with the override, unless it is empty.
+func overrideString(value *string, override string) {
+	if override != "" {
+		*value = override
+	}
+}
+
+func (d definition) originTexts() (map[origin.Key]string, error) {
+	texts := map[origin.Key]string{}
+	for value, text := range d.ControlOrigins {
+		found := false
+		for key, mapping := range origin.GetSourceMappings() {
+			if mapping.IsYAMLMappingEqualTo(value) {
+				texts[key] = text
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown control origination %q", value)
+		}
+	}
+	return texts, nil
+}
+
+func (d definition) implementationTexts() (map[implementation.Key]string, error) {
+	texts := map[implementation.Key]string{}
+	for value, text := range d.ImplementationStatuses {
+		found := false
+		for key, mapping := range implementation.GetSourceMappings() {
+			if mapping.IsYAMLMappingEqualTo(value) {
+				texts[key] = text
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown implementation status %q", value)
+		}
+	}
+	return texts, nil
+}
+
+// toProfile converts the definition to a Profile, starting from the base profile.
+func (d definition) toProfile() (p Profile, err error) {
+	base := d.Base
+	if base == "" {
+		base = V2_1
+	}
+	p, err = Get(base)
+	if err != nil {
+		return
+	}
+	p.Version = d.Version
+	// a loaded profile is selected explicitly rather than detected.
+	p.DetectionRegex = nil
+
+	overrideString(&p.SummaryTablesXPath, d.SummaryTablesXPath)
+	overrideString(&p.NarrativeTablesXPath, d.NarrativeTablesXPath)
+	if err = checkFormat("narrative_tables_xpath", p.NarrativeTablesXPath, 1); err != nil {
+		return
+	}
+	overrideString(&p.EnhancementFormat, d.EnhancementFormat)
+	if err = checkFormat("enhancement_format", p.EnhancementFormat, 2); err != nil {
+		return
+	}
+	if d.ControlRegex != "" {
+		if p.ControlRegex, err = compileRegex("control_regex", d.ControlRegex, 2); err != nil {
+			return
+		}
+	}
+	if d.PartRegex != "" {
+		if p.PartRegex, err = compileRegex("part_regex", d.PartRegex, 1); err != nil {
+			return
+		}
+	}
+
+	overrideString(&p.Labels.ResponsibleRole, d.Labels.ResponsibleRole)
+	overrideString(&p.Labels.Parameter, d.Labels.Parameter)
+	overrideString(&p.Labels.ControlOrigination, d.Labels.ControlOrigination)
+	overrideString(&p.Labels.ImplementationStatus, d.Labels.ImplementationStatus)
+
+	if p.ControlOrigins, err = d.originTexts(); err != nil {
+		return
+	}
+	p.ImplementationStatuses, err = d.implementationTexts()
+	return
+}
+
+// LoadFrom reads a template profile from the YAML file at the provided path, e.g. for an agency-specific variant of
+// the SSP template. The version defaults to the name of the file.
+func LoadFrom(path string) (Profile, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	var d definition
+	err = yaml.Unmarshal(content, &d)
+	if err != nil {
+		return Profile{}, fmt.Errorf("unable to parse %s: %s", path, err)
+	}
+	if d.Version == "" {
+		d.Version = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	p, err := d.toProfile()
+	if err != nil {
+		return Profile{}, fmt.Errorf("invalid template profile %s: %s", path, err)
+	}
+	return p, nil
+}
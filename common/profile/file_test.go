@@ -0,0 +1,67 @@
+package profile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	"github.com/opencontrol/fedramp-templater/common/source"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// loadProfile writes the content to a temporary profile file and loads it.
+func loadProfile(content string) (profile.Profile, error) {
+	dir, err := ioutil.TempDir("", "profile")
+	Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "custom.yaml")
+	Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	return profile.LoadFrom(path)
+}
+
+var _ = Describe("LoadFrom", func() {
+	It("overrides the fields of the base profile", func() {
+		p, err := profile.LoadFrom(filepath.Join("..", "..", "fixtures", "profiles", "agency.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.Version).To(Equal("agency"))
+		Expect(p.NarrativeTablesXPathFor("AC-2 (1)")).To(Equal(
+			"//w:tbl[contains(normalize-space(.), 'AC-2(1) How is the control implemented?')]"))
+		part, found := p.Part("Item b")
+		Expect(found).To(BeTrue())
+		Expect(part).To(Equal("b"))
+		Expect(p.Labels.ResponsibleRole).To(Equal("Role Owner"))
+		Expect(p.Labels.Parameter).To(Equal("Parameter"))
+		Expect(p.OriginMappings()[origin.SharedOrigination][source.SSP]).To(Equal("Shared Responsibility"))
+		Expect(p.OriginMappings()[origin.InheritedOrigination][source.SSP]).To(Equal("Inherited"))
+		Expect(p.ImplementationMappings()[implementation.ImplementedImplementation][source.SSP]).To(
+			Equal("Fully implemented"))
+	})
+
+	It("defaults the version to the name of the file", func() {
+		p, err := loadProfile("labels:\n  parameter: Value\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Version).To(Equal("custom"))
+		Expect(p.EnhancementFormat).To(Equal("%s (%s)"))
+	})
+
+	It("gives an error for a regex without the submatches", func() {
+		_, err := loadProfile("control_regex: '[A-Z]{2}-\\d+'\n")
+		Expect(err).To(MatchError(ContainSubstring("control_regex must have 2 submatches, found 0")))
+	})
+
+	It("gives an error for an unknown checkbox", func() {
+		_, err := loadProfile("control_origins:\n  unknown: Unknown\n")
+		Expect(err).To(MatchError(ContainSubstring(`unknown control origination "unknown"`)))
+	})
+
+	It("gives an error for an unknown base", func() {
+		_, err := loadProfile("base: v1.0\n")
+		Expect(err).To(MatchError(ContainSubstring(`unknown template version "v1.0"`)))
+	})
+})
@@ -0,0 +1,198 @@
+package profile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/source"
+)
+
+// Template versions.
+const (
+	V2_1 = "v2.1"
+	Rev5 = "rev5"
+)
+
+// Profile describes how the control tables are laid out in a particular version of the SSP template.
+type Profile struct {
+	Version string
+	// SummaryTablesXPath is the pattern used to find the summary tables within an SSP's XML.
+	SummaryTablesXPath string
+	// NarrativeTablesXPath is the pattern used to find the narrative tables within an SSP's XML. It is formatted with
+	// the control, as it is written in the document, or an empty string to find all of them.
+	NarrativeTablesXPath string
+	// ControlRegex matches the control in the header of a table, with the control (e.g. `AC-2`) and the enhancement
+	// number, if any, as submatches.
+	ControlRegex *regexp.Regexp
+	// EnhancementFormat is how a control enhancement is written in the document, formatted with the control and the
+	// enhancement number.
+	EnhancementFormat string
+	// PartRegex matches the part of a narrative row, with the part (e.g. `a`) as a submatch.
+	PartRegex *regexp.Regexp
+	// DetectionRegex matches the labels of the tables (see Detect) that only appear in documents of this version, if
+	// any.
+	DetectionRegex *regexp.Regexp
+	// Labels are the text at the start of the cells of the summary tables.
+	Labels Labels
+	// ControlOrigins overrides the text next to the checkbox of the control originations, if any.
+	ControlOrigins map[origin.Key]string
+	// ImplementationStatuses overrides the text next to the checkbox of the implementation statuses, if any.
+	ImplementationStatuses map[implementation.Key]string
+}
+
+// Labels are the text at the start of the cells of the summary tables, which identify the fields.
+type Labels struct {
+	ResponsibleRole      string `yaml:"responsible_role"`
+	Parameter            string `yaml:"parameter"`
+	ControlOrigination   string `yaml:"control_origination"`
+	ImplementationStatus string `yaml:"implementation_status"`
+}
+
+// defaultLabels are the labels of the FedRAMP templates.
+var defaultLabels = Labels{
+	ResponsibleRole:      "Responsible Role",
+	Parameter:            "Parameter",
+	ControlOrigination:   "Control Origination",
+	ImplementationStatus: "Implementation Status",
+}
+
+// OriginMappings returns the mapping of each control origination to their respective sources, with the text of the
+// template.
+func (p Profile) OriginMappings() map[origin.Key]origin.SrcMapping {
+	mappings := origin.GetSourceMappings()
+	for key, text := range p.ControlOrigins {
+		mappings[key][source.SSP] = text
+	}
+	return mappings
+}
+
+// ImplementationMappings returns the mapping of each implementation status to their respective sources, with the text
+// of the template.
+func (p Profile) ImplementationMappings() map[implementation.Key]implementation.SrcMapping {
+	mappings := implementation.GetSourceMappings()
+	for key, text := range p.ImplementationStatuses {
+		mappings[key][source.SSP] = text
+	}
+	return mappings
+}
+
+// enhancementRegex matches the control enhancements, as they are written in the OpenControls, e.g. `AC-2 (1)`.
+var enhancementRegex = regexp.MustCompile(`^([A-Z]{2}-\d+) \((\d+)\)$`)
+
+// ControlName returns the control found in the text (e.g. the header of a table), in the format used by the
+// OpenControls (e.g. `AC-2 (1)`), or an empty string if there isn't one.
+func (p Profile) ControlName(text string) string {
+	subMatches := p.ControlRegex.FindStringSubmatch(text)
+	if len(subMatches) != 3 {
+		return ""
+	}
+	if subMatches[2] == "" {
+		return subMatches[1]
+	}
+	return fmt.Sprintf("%s (%s)", subMatches[1], subMatches[2])
+}
+
+// DocumentControlName converts the control from the format used by the OpenControls to how it is written in the
+// document, e.g. `AC-2 (1)` to `AC-2(1)`.
+func (p Profile) DocumentControlName(control string) string {
+	subMatches := enhancementRegex.FindStringSubmatch(control)
+	if len(subMatches) != 3 {
+		return control
+	}
+	return fmt.Sprintf(p.EnhancementFormat, subMatches[1], subMatches[2])
+}
+
+// NarrativeTablesXPathFor returns the pattern used to find the narrative tables of the control, or all of them if the
+// control is an empty string.
+func (p Profile) NarrativeTablesXPathFor(control string) string {
+	return fmt.Sprintf(p.NarrativeTablesXPath, p.DocumentControlName(control))
+}
+
+// Part returns the part found in the text (e.g. the first cell of a narrative row), and whether there is one.
+func (p Profile) Part(text string) (string, bool) {
+	subMatches := p.PartRegex.FindStringSubmatch(text)
+	if len(subMatches) != 2 {
+		return "", false
+	}
+	return subMatches[1], true
+}
+
+// Matches returns true if the labels of the tables (see Detect) are of this version of the template.
+func (p Profile) Matches(text string) bool {
+	return p.DetectionRegex != nil && p.DetectionRegex.MatchString(text)
+}
+
+var profiles = []Profile{
+	{
+		// Rev 5 writes the enhancements without a space, e.g. `AC-2(1)`, and the parts with a period, e.g. `Part a.`.
+		Version: Rev5,
+		SummaryTablesXPath: "//w:tbl[contains(normalize-space(.), 'Control Summary Information') or " +
+			"contains(normalize-space(.), 'Control Enhancement Summary Information')]",
+		NarrativeTablesXPath: "//w:tbl[contains(normalize-space(.), '%s What is the solution and how is it implemented?')]",
+		ControlRegex:         regexp.MustCompile(`([A-Z]{2}-\d+)(?: ?\((\d+)\))?`),
+		EnhancementFormat:    "%s(%s)",
+		PartRegex:            regexp.MustCompile(`Part ([a-z])\.?`),
+		// the enhancements and the parts in the control tables tell the versions apart, rather than the prose.
+		DetectionRegex: regexp.MustCompile(`(?m)^[A-Z]{2}-\d+\(\d+\) ?(?:Control Enhancement Summary Information|` +
+			`What is the solution)|^Part [a-z]\.$`),
+		Labels: defaultLabels,
+	},
+	{
+		Version: V2_1,
+		SummaryTablesXPath: "//w:tbl[contains(normalize-space(.), 'Control Summary') or " +
+			"contains(normalize-space(.), 'Control Enhancement Summary')]",
+		NarrativeTablesXPath: "//w:tbl[contains(normalize-space(.), '%s What is the solution and how is it implemented?')]",
+		ControlRegex:         regexp.MustCompile(`([A-Z]{2}-\d+)(?: +\((\d+)\))?`),
+		EnhancementFormat:    "%s (%s)",
+		PartRegex:            regexp.MustCompile(`Part ([a-z])`),
+		DetectionRegex: regexp.MustCompile(`(?m)^[A-Z]{2}-\d+ +\(\d+\) ?(?:Control Enhancement Summary Information|` +
+			`What is the solution)|^Part [a-z]$`),
+		Labels: defaultLabels,
+	},
+}
+
+// Versions returns the versions of the template that are supported.
+func Versions() []string {
+	versions := []string{}
+	for _, p := range profiles {
+		versions = append(versions, p.Version)
+	}
+	return versions
+}
+
+// Get returns the Profile of the version of the template.
+func Get(version string) (Profile, error) {
+	for _, p := range profiles {
+		if p.Version == version {
+			return p, nil
+		}
+	}
+	return Profile{}, fmt.Errorf("unknown template version %q, expected one of: %s", version,
+		strings.Join(Versions(), ", "))
+}
+
+// Default returns the Profile of the v2.1 template, which is used when the version isn't detected.
+func Default() Profile {
+	p, _ := Get(V2_1)
+	return p
+}
+
+// Detect returns the Profile of the version of the template that the labels of the tables of a document are from, i.e.
+// the first row of each table and the first cell of each of the other rows, one per line. It returns the default
+// Profile and false if the version can't be told, e.g. for a document that only has controls without any enhancements
+// or parts, or one that matches more than one of the versions.
+func Detect(text string) (Profile, bool) {
+	matches := []Profile{}
+	for _, p := range profiles {
+		if p.Matches(text) {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) != 1 {
+		return Default(), false
+	}
+	return matches[0], true
+}
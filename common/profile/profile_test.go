@@ -0,0 +1,98 @@
+package profile_test
+
+import (
+	"github.com/opencontrol/fedramp-templater/common/profile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+	Describe("Get", func() {
+		It("returns the profile of the version", func() {
+			p, err := profile.Get(profile.Rev5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Version).To(Equal(profile.Rev5))
+		})
+		It("gives an error for an unknown version", func() {
+			_, err := profile.Get("v1.0")
+			Expect(err).To(MatchError(`unknown template version "v1.0", expected one of: rev5, v2.1`))
+		})
+	})
+
+	Describe("Detect", func() {
+		It("detects the Rev 5 template from the control enhancements", func() {
+			p, detected := profile.Detect("AC-2 Control Summary Information\nAC-2(1)Control Enhancement Summary Information")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.Rev5))
+		})
+		It("detects the Rev 5 template from the narrative tables", func() {
+			p, detected := profile.Detect("AC-2(1) What is the solution and how is it implemented?")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.Rev5))
+		})
+		It("detects the Rev 5 template from the parts without any enhancements", func() {
+			p, detected := profile.Detect("AC-2 What is the solution and how is it implemented?\nPart a.\nPart b.")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.Rev5))
+		})
+		It("detects the v2.1 template from the control enhancements or the parts", func() {
+			p, detected := profile.Detect("AC-2 (1) Control Enhancement Summary Information\nAC-2 (1) What is the solution")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.V2_1))
+			p, detected = profile.Detect("AC-2 What is the solution and how is it implemented?\nPart a\nPart b")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.V2_1))
+		})
+		It("doesn't detect the Rev 5 template from the controls or parts within a label", func() {
+			p, detected := profile.Detect("AC-2 (1) Control Enhancement Summary Information, see AC-2(1) What is the " +
+				"solution in Part a.")
+			Expect(detected).To(BeTrue())
+			Expect(p.Version).To(Equal(profile.V2_1))
+		})
+		It("defaults to the v2.1 template when the version can't be told", func() {
+			p, detected := profile.Detect("AC-2 Control Summary Information\nAC-2 What is the solution")
+			Expect(detected).To(BeFalse())
+			Expect(p.Version).To(Equal(profile.V2_1))
+			_, detected = profile.Detect("AC-2(1) What is the solution\nAC-2 (2) What is the solution")
+			Expect(detected).To(BeFalse())
+		})
+	})
+
+	Describe("ControlName", func() {
+		It("returns the control enhancement in the format of the OpenControls", func() {
+			p, _ := profile.Get(profile.Rev5)
+			Expect(p.ControlName("AC-2(1) Control Enhancement Summary Information")).To(Equal("AC-2 (1)"))
+			Expect(profile.Default().ControlName("AC-2  (1) Control Enhancement Summary Information")).To(Equal("AC-2 (1)"))
+		})
+		It("returns the control", func() {
+			p, _ := profile.Get(profile.Rev5)
+			Expect(p.ControlName("AC-2 Control Summary Information")).To(Equal("AC-2"))
+		})
+		It("returns an empty string if there isn't a control", func() {
+			Expect(profile.Default().ControlName("Control Summary Information")).To(Equal(""))
+		})
+	})
+
+	Describe("DocumentControlName", func() {
+		It("converts the control enhancement to the format of the document", func() {
+			p, _ := profile.Get(profile.Rev5)
+			Expect(p.DocumentControlName("AC-2 (1)")).To(Equal("AC-2(1)"))
+			Expect(profile.Default().DocumentControlName("AC-2 (1)")).To(Equal("AC-2 (1)"))
+			Expect(p.DocumentControlName("AC-2")).To(Equal("AC-2"))
+		})
+	})
+
+	Describe("Part", func() {
+		It("returns the part", func() {
+			p, _ := profile.Get(profile.Rev5)
+			part, found := p.Part("Part a.")
+			Expect(found).To(BeTrue())
+			Expect(part).To(Equal("a"))
+		})
+		It("returns false if there isn't a part", func() {
+			_, found := profile.Default().Part("Justification")
+			Expect(found).To(BeFalse())
+		})
+	})
+})
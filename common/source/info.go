@@ -8,4 +8,6 @@ const (
 	SSP Source = "SSP"
 	// YAML indicates that the information is located in a YAML file.
 	YAML Source = "YAML"
+	// OSCAL indicates that the information is located in an OSCAL document, as a FedRAMP prop value.
+	OSCAL Source = "OSCAL"
 )
@@ -0,0 +1,164 @@
+package textdiff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Operation is the type of change for a single item in a diff.
+type Operation uint8
+
+const (
+	// Equal indicates that the item is in both inputs.
+	Equal Operation = iota
+	// Delete indicates that the item is only in the first input.
+	Delete
+	// Insert indicates that the item is only in the second input.
+	Insert
+)
+
+// Edit is a single item of a diff along with how it changed.
+type Edit struct {
+	Op   Operation
+	Text string
+}
+
+// contextLines is the number of unchanged lines shown around each change in the unified diff.
+const contextLines = 3
+
+// Diff computes the shortest list of edits that transforms `a` into `b` using the longest common subsequence.
+func Diff(a, b []string) []Edit {
+	// lcs[i][j] holds the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := []Edit{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit{Delete, a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, Edit{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, Edit{Insert, b[j]})
+	}
+	return edits
+}
+
+// hunk is a range of edits that will be printed together in the unified diff.
+type hunk struct {
+	start, end int
+}
+
+// findHunks groups the changed edits, along with their surrounding context, into hunks.
+func findHunks(edits []Edit) []hunk {
+	hunks := []hunk{}
+	for idx, edit := range edits {
+		if edit.Op == Equal {
+			continue
+		}
+		start := idx - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := idx + contextLines + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+		// merge with the previous hunk if they overlap or touch.
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunk{start, end})
+	}
+	return hunks
+}
+
+// hunkRange formats the line range of a hunk for one of the inputs, e.g. `1,3`.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// an empty range refers to the line before the hunk.
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// Unified returns the diff of the lines `a` and `b` in the unified format. Returns an empty string if the lines are the same.
+func Unified(a, b []string, fromLabel, toLabel string) string {
+	edits := Diff(a, b)
+	hunks := findHunks(edits)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", fromLabel, toLabel)
+	// the line number in `a` and `b` of the current edit.
+	aLine, bLine := 0, 0
+	editIdx := 0
+	for _, h := range hunks {
+		// advance the line counters to the start of the hunk.
+		for ; editIdx < h.start; editIdx++ {
+			aLine, bLine = advance(edits[editIdx].Op, aLine, bLine)
+		}
+		aCount, bCount := 0, 0
+		for _, edit := range edits[h.start:h.end] {
+			aCount, bCount = advance(edit.Op, aCount, bCount)
+		}
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+		for ; editIdx < h.end; editIdx++ {
+			edit := edits[editIdx]
+			switch edit.Op {
+			case Equal:
+				fmt.Fprintf(buf, " %s\n", edit.Text)
+			case Delete:
+				fmt.Fprintf(buf, "-%s\n", edit.Text)
+			case Insert:
+				fmt.Fprintf(buf, "+%s\n", edit.Text)
+			}
+			aLine, bLine = advance(edit.Op, aLine, bLine)
+		}
+	}
+	return buf.String()
+}
+
+// advance moves the line counters of each input forward according to the operation.
+func advance(op Operation, aLine, bLine int) (int, int) {
+	switch op {
+	case Equal:
+		return aLine + 1, bLine + 1
+	case Delete:
+		return aLine + 1, bLine
+	default:
+		return aLine, bLine + 1
+	}
+}
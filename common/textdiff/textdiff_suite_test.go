@@ -0,0 +1,13 @@
+package textdiff_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTextdiff(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Textdiff Suite")
+}
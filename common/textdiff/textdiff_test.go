@@ -0,0 +1,40 @@
+package textdiff_test
+
+import (
+	"github.com/opencontrol/fedramp-templater/common/textdiff"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Textdiff", func() {
+	Describe("Diff", func() {
+		It("marks the items that were removed and added", func() {
+			edits := textdiff.Diff([]string{"a", "b", "c"}, []string{"a", "x", "c"})
+			Expect(edits).To(Equal([]textdiff.Edit{
+				{textdiff.Equal, "a"},
+				{textdiff.Delete, "b"},
+				{textdiff.Insert, "x"},
+				{textdiff.Equal, "c"},
+			}))
+		})
+	})
+
+	Describe("Unified", func() {
+		It("returns an empty string when there are no changes", func() {
+			Expect(textdiff.Unified([]string{"a"}, []string{"a"}, "SSP", "YAML")).To(Equal(""))
+		})
+
+		It("returns the changes with their surrounding context", func() {
+			a := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}
+			b := []string{"1", "2", "3", "4", "five", "6", "7", "8", "9"}
+			Expect(textdiff.Unified(a, b, "SSP", "YAML")).To(Equal("--- SSP\n+++ YAML\n" +
+				"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"))
+		})
+
+		It("handles an empty input", func() {
+			Expect(textdiff.Unified([]string{}, []string{"new"}, "SSP", "YAML")).To(Equal("--- SSP\n+++ YAML\n" +
+				"@@ -0,0 +1 @@\n+new\n"))
+		})
+	})
+})
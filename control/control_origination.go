@@ -6,6 +6,7 @@ import (
 	"github.com/opencontrol/fedramp-templater/common/origin"
 	"github.com/opencontrol/fedramp-templater/docx"
 	"github.com/opencontrol/fedramp-templater/docx/helper"
+	xmlHelper "github.com/opencontrol/fedramp-templater/xml/helper"
 	"gopkg.in/fatih/set.v0"
 )
 
@@ -25,10 +26,9 @@ func (o *controlOrigination) getCheckedOrigins() *set.Set {
 	return checkedControlOrigins
 }
 
-func detectControlOriginKeyFromDoc(textNodes []xml.Node) origin.Key {
+func detectControlOriginKeyFromDoc(textNodes []xml.Node, mappings map[origin.Key]origin.SrcMapping) origin.Key {
 	textField := helper.ConcatTextNodes(textNodes)
-	controlOriginMappings := origin.GetSourceMappings()
-	for controlOrigin, controlOriginMapping := range controlOriginMappings {
+	for controlOrigin, controlOriginMapping := range mappings {
 		if controlOriginMapping.IsDocMappingASubstrOf(textField) {
 			return controlOrigin
 		}
@@ -38,14 +38,16 @@ func detectControlOriginKeyFromDoc(textNodes []xml.Node) origin.Key {
 
 func newControlOrigination(tbl *table) (*controlOrigination, error) {
 	// Find the control origination row.
-	rows, err := tbl.Root.Search(".//w:tc[starts-with(normalize-space(.), 'Control Origination')]")
+	label := tbl.profile.Labels.ControlOrigination
+	rows, err := tbl.Root.Search(fmt.Sprintf(".//w:tc[starts-with(normalize-space(.), %s)]", xmlHelper.Literal(label)))
 	if err != nil {
 		return nil, err
 	}
 	// Check that we only found the one cell.
 	if len(rows) != 1 {
-		return nil, fmt.Errorf("Unable to find Control Origination cell")
+		return nil, fmt.Errorf("Unable to find %s cell", label)
 	}
+	mappings := tbl.profile.OriginMappings()
 	// Each checkbox is contained in a paragraph.
 	origins := make(map[origin.Key]*docx.CheckBox)
 	paragraphs, err := rows[0].Search(".//w:p")
@@ -66,7 +68,7 @@ func newControlOrigination(tbl *table) (*controlOrigination, error) {
 		}
 
 		// 3. Detect the key for the map.
-		controlOriginKey := detectControlOriginKeyFromDoc(textNodes)
+		controlOriginKey := detectControlOriginKeyFromDoc(textNodes, mappings)
 		// if couldn't detect an origin, skip.
 		if controlOriginKey == origin.NoOrigin {
 			continue
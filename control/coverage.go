@@ -0,0 +1,108 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+)
+
+// fieldName returns the name of the field with the key, e.g. `Narrative a`.
+func fieldName(field, key string) string {
+	if key == "" {
+		return field
+	}
+	return field + " " + key
+}
+
+// FieldCoverage is whether a field of a control table would be filled from the OpenControl data.
+type FieldCoverage struct {
+	Control string
+	// Field is the type of the field, e.g. `Parameter`.
+	Field string
+	// Key is the ID of a parameter or the part of a narrative, e.g. `a`. It is empty for the other fields.
+	Key    string
+	Filled bool
+}
+
+// Coverage returns whether each field of the table (the responsible role, each parameter, the control origination and
+// the implementation status) would be filled from the data. Fields of a control that isn't in the certification of
+// the data aren't filled.
+func (st *SummaryTable) Coverage(openControlData opencontrols.Data) ([]FieldCoverage, error) {
+	control, err := st.controlName()
+	if err != nil {
+		return nil, err
+	}
+	inCertification := openControlData.InCertification(control)
+	fields := []FieldCoverage{}
+
+	if _, err := findResponsibleRole(st); err == nil {
+		fields = append(fields, FieldCoverage{
+			Control: control,
+			Field:   responsibleRoleField,
+			Filled:  inCertification && opencontrols.HasInformation(openControlData.GetResponsibleRoles(control)),
+		})
+	}
+
+	parameters, err := findParameters(st)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, paramCell := range parameters.List() {
+		ids = append(ids, paramCell.(*Parameter).getId())
+	}
+	// the parameters are in a set, so sort them to report them in the same order every time.
+	sort.Strings(ids)
+	for _, id := range ids {
+		fields = append(fields, FieldCoverage{
+			Control: control,
+			Field:   parameterField,
+			Key:     id,
+			Filled:  inCertification && opencontrols.HasInformation(openControlData.GetParameter(control, id)),
+		})
+	}
+
+	origins := openControlData.GetControlOrigins(control)
+	statuses := openControlData.GetImplementationStatuses(control)
+	fields = append(fields,
+		FieldCoverage{
+			Control: control,
+			Field:   controlOriginationField,
+			Filled:  inCertification && origins.GetCheckedOrigins().Size() > 0,
+		},
+		FieldCoverage{
+			Control: control,
+			Field:   implementationStatusField,
+			Filled:  inCertification && statuses.GetCheckedImplementationStatuses().Size() > 0,
+		},
+	)
+	return fields, nil
+}
+
+// Coverage returns whether each narrative section/part of the table would be filled from the data.
+func (t *NarrativeTable) Coverage(openControlData opencontrols.Data) ([]FieldCoverage, error) {
+	control, err := t.table.controlName()
+	if err != nil {
+		return nil, err
+	}
+	inCertification := openControlData.InCertification(control)
+
+	rows, err := t.SectionRows()
+	if err != nil {
+		return nil, err
+	}
+	fields := []FieldCoverage{}
+	for _, row := range rows {
+		key, err := t.section(row).GetKey()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, FieldCoverage{
+			Control: control,
+			Field:   narrativeField,
+			Key:     key,
+			Filled:  inCertification && opencontrols.HasInformation(openControlData.GetNarrative(control, key)),
+		})
+	}
+	return fields, nil
+}
@@ -0,0 +1,65 @@
+package control
+
+import (
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Coverage", func() {
+	It("reports the fields of a summary table", func() {
+		st, err := NewSummaryTable(getTable("AC-2"))
+		Expect(err).NotTo(HaveOccurred())
+
+		fields, err := st.Coverage(fixtures.LoadOpenControlFixture())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]FieldCoverage{
+			{Control: "AC-2", Field: "Responsible Role", Filled: true},
+			{Control: "AC-2", Field: "Control Origination", Filled: true},
+			{Control: "AC-2", Field: "Implementation Status", Filled: true},
+		}))
+	})
+
+	It("reports the fields of a control without a justification as empty", func() {
+		st, err := NewSummaryTable(getTable("AC-6"))
+		Expect(err).NotTo(HaveOccurred())
+
+		fields, err := st.Coverage(fixtures.LoadOpenControlFixture())
+
+		Expect(err).NotTo(HaveOccurred())
+		for _, field := range fields {
+			Expect(field.Filled).To(BeFalse())
+		}
+	})
+
+	It("reports the fields of a control that isn't in the certification as empty", func() {
+		st, err := NewSummaryTable(getTable("AC-2"))
+		Expect(err).NotTo(HaveOccurred())
+		openControlData := fixtures.LoadOpenControlFixture()
+		openControlData.SetCertification(opencontrols.NewCertification("Test", []string{"AC-6"}))
+
+		fields, err := st.Coverage(openControlData)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields[0].Filled).To(BeFalse())
+	})
+
+	It("reports the narrative parts of a narrative table", func() {
+		doc := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+		defer doc.Close()
+		root, err := doc.NarrativeTable("AC-2")
+		Expect(err).NotTo(HaveOccurred())
+		table := NewNarrativeTable(root)
+
+		fields, err := table.Coverage(fixtures.LoadOpenControlFixture())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(HaveLen(11))
+		Expect(fields[0]).To(Equal(FieldCoverage{Control: "AC-2", Field: "Narrative", Key: "a", Filled: true}))
+		Expect(fields[1].Filled).To(BeTrue())
+		Expect(fields[2]).To(Equal(FieldCoverage{Control: "AC-2", Field: "Narrative", Key: "c", Filled: false}))
+	})
+})
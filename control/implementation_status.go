@@ -6,6 +6,7 @@ import (
 	"github.com/opencontrol/fedramp-templater/common/implementation"
 	"github.com/opencontrol/fedramp-templater/docx"
 	"github.com/opencontrol/fedramp-templater/docx/helper"
+	xmlHelper "github.com/opencontrol/fedramp-templater/xml/helper"
 	"gopkg.in/fatih/set.v0"
 )
 
@@ -25,10 +26,9 @@ func (i *implementationStatus) getCheckedImplementationStatuses() *set.Set {
 	return checkedImplementations
 }
 
-func detectImplementationStatusKeyFromDoc(textNodes []xml.Node) implementation.Key {
+func detectImplementationStatusKeyFromDoc(textNodes []xml.Node, mappings map[implementation.Key]implementation.SrcMapping) implementation.Key {
 	textField := helper.ConcatTextNodes(textNodes)
-	ImplementationStatusMappings := implementation.GetSourceMappings()
-	for implementationStatus, implementationStatusMapping := range ImplementationStatusMappings {
+	for implementationStatus, implementationStatusMapping := range mappings {
 		if implementationStatusMapping.IsDocMappingASubstrOf(textField) {
 			return implementationStatus
 		}
@@ -38,14 +38,16 @@ func detectImplementationStatusKeyFromDoc(textNodes []xml.Node) implementation.K
 
 func newImplementationStatus(tbl *table) (*implementationStatus, error) {
 	// Find the control origination row.
-	rows, err := tbl.Root.Search(".//w:tc[starts-with(normalize-space(.), 'Implementation Status')]")
+	label := tbl.profile.Labels.ImplementationStatus
+	rows, err := tbl.Root.Search(fmt.Sprintf(".//w:tc[starts-with(normalize-space(.), %s)]", xmlHelper.Literal(label)))
 	if err != nil {
 		return nil, err
 	}
 	// Check that we only found the one cell.
 	if len(rows) != 1 {
-		return nil, fmt.Errorf("Unable to find Implementation Status cell")
+		return nil, fmt.Errorf("Unable to find %s cell", label)
 	}
+	mappings := tbl.profile.ImplementationMappings()
 	// Each checkbox is contained in a paragraph.
 	statuses := make(map[implementation.Key]*docx.CheckBox)
 	paragraphs, err := rows[0].Search(".//w:p")
@@ -66,7 +68,7 @@ func newImplementationStatus(tbl *table) (*implementationStatus, error) {
 		}
 
 		// 3. Detect the key for the map.
-		implementationStatusKey := detectImplementationStatusKeyFromDoc(textNodes)
+		implementationStatusKey := detectImplementationStatusKeyFromDoc(textNodes, mappings)
 		// if couldn't detect an implementation, skip.
 		if implementationStatusKey == implementation.NoStatus {
 			continue
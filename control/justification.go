@@ -0,0 +1,52 @@
+package control
+
+import (
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+)
+
+// Justification is the information about a control that is filled in the SSP.
+type Justification struct {
+	Control                string
+	ResponsibleRole        string
+	Parameters             map[string]string
+	ControlOrigins         []origin.Key
+	ImplementationStatuses []implementation.Key
+	Narratives             map[string]string
+}
+
+// NewJustification creates an empty Justification for the control.
+func NewJustification(control string) Justification {
+	return Justification{
+		Control:    control,
+		Parameters: map[string]string{},
+		Narratives: map[string]string{},
+	}
+}
+
+// IsEmpty returns true if none of the fields are filled.
+func (j Justification) IsEmpty() bool {
+	return j.ResponsibleRole == "" && len(j.Parameters) == 0 && len(j.ControlOrigins) == 0 &&
+		len(j.ImplementationStatuses) == 0 && len(j.Narratives) == 0
+}
+
+// Justifications is the Justification of each control in an SSP, keyed by control.
+type Justifications map[string]Justification
+
+// NewJustifications creates the Justifications for the list of controls.
+func NewJustifications(list []Justification) Justifications {
+	justifications := Justifications{}
+	for _, justification := range list {
+		justifications[justification.Control] = justification
+	}
+	return justifications
+}
+
+// Get returns the Justification of the control, or an empty one if the control isn't in the SSP.
+func (j Justifications) Get(control string) Justification {
+	justification, exists := j[control]
+	if !exists {
+		return NewJustification(control)
+	}
+	return justification
+}
@@ -0,0 +1,47 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+)
+
+// Git-style markers that surround the conflicting values of a field in the merged SSP.
+var (
+	conflictStartMarker = "<<<<<<< " + string(source.SSP)
+	conflictSeparator   = "======="
+	conflictEndMarker   = ">>>>>>> " + string(source.YAML)
+)
+
+// mergeValues returns the result of the three-way merge of a field, given its value in the base SSP, the edited SSP and
+// the YAML. The changes conflict when both the SSP and the YAML changed the field to different values.
+func mergeValues(base, ssp, yaml string) (merged string, conflict bool) {
+	switch {
+	case ssp == base || ssp == yaml:
+		return yaml, false
+	case yaml == base:
+		return ssp, false
+	default:
+		return "", true
+	}
+}
+
+// mergeYAMLValue returns the value of a single-line field in the YAML for the three-way merge, the way that the cell
+// stores it. When the YAML doesn't have any information for the field, it didn't change the field from the base SSP.
+func mergeYAMLValue(base, yaml string) string {
+	if !opencontrols.HasInformation(yaml) {
+		return base
+	}
+	return singleLineValue(yaml)
+}
+
+// conflictLines returns the lines to put in a narrative cell to mark the conflicting values.
+func conflictLines(ssp, yaml string) []string {
+	return []string{conflictStartMarker, ssp, conflictSeparator, yaml, conflictEndMarker}
+}
+
+// conflictText returns the text to put in a single-line cell to mark the conflicting values.
+func conflictText(ssp, yaml string) string {
+	return strings.Join(conflictLines(ssp, yaml), " ")
+}
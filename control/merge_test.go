@@ -0,0 +1,207 @@
+package control
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// componentWithParameter returns a component that justifies the control with the AC-2(a) parameter.
+func componentWithParameter(name, key, control, parameter string) opencontrols.Component {
+	component := opencontrols.NewComponent(name, key)
+	satisfies := opencontrols.NewSatisfies(control)
+	satisfies.Parameters = []opencontrols.Section{{Key: "AC-2(a)", Text: parameter}}
+	component.Satisfies = append(component.Satisfies, satisfies)
+	return component
+}
+
+// getStatusTable returns a summary table for AC-2 with a checkbox for each of the implementation statuses of the v2.1
+// template, where the provided ones are checked.
+func getStatusTable(checked ...string) xml.Node {
+	content := `<w:tbl xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"` +
+		` xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:tr><w:tc><w:p><w:r><w:t>AC-2</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Responsible Role:</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Implementation Status (check all that apply):</w:t></w:r></w:p>`
+	for _, status := range []string{"Implemented", "Partially implemented", "Planned"} {
+		isChecked := false
+		for _, checkedStatus := range checked {
+			isChecked = isChecked || checkedStatus == status
+		}
+		content += checkBoxParagraph(status, isChecked)
+	}
+	content += `</w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Control Origination (check all that apply):</w:t></w:r></w:p></w:tc></w:tr>` +
+		`</w:tbl>`
+
+	doc, err := helper.ParseXML([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	return doc.Root()
+}
+
+// componentWithStatus returns a component that justifies AC-2 with the implementation status.
+func componentWithStatus(status string) opencontrols.Component {
+	component := opencontrols.NewComponent("My System", "my_system")
+	satisfies := opencontrols.NewSatisfies("AC-2")
+	satisfies.SetImplementationStatuses([]string{status})
+	component.Satisfies = append(component.Satisfies, satisfies)
+	return component
+}
+
+var _ = Describe("Merge", func() {
+	Describe("mergeValues", func() {
+		It("takes the YAML value when the SSP wasn't changed", func() {
+			merged, conflict := mergeValues("base", "base", "yaml")
+			Expect(conflict).To(BeFalse())
+			Expect(merged).To(Equal("yaml"))
+		})
+
+		It("keeps the SSP value when the YAML wasn't changed", func() {
+			merged, conflict := mergeValues("base", "ssp", "base")
+			Expect(conflict).To(BeFalse())
+			Expect(merged).To(Equal("ssp"))
+		})
+
+		It("doesn't conflict when both were changed to the same value", func() {
+			merged, conflict := mergeValues("base", "same", "same")
+			Expect(conflict).To(BeFalse())
+			Expect(merged).To(Equal("same"))
+		})
+
+		It("conflicts when both were changed to different values", func() {
+			_, conflict := mergeValues("base", "ssp", "yaml")
+			Expect(conflict).To(BeTrue())
+		})
+	})
+
+	Describe("mergeYAMLValue", func() {
+		It("converts the lines of the YAML to a single line", func() {
+			Expect(mergeYAMLValue("base", "AWS Staff\nSecurity Team\n")).To(Equal("AWS Staff, Security Team"))
+		})
+
+		It("keeps the base value when the YAML doesn't have any information", func() {
+			Expect(mergeYAMLValue("base", "")).To(Equal("base"))
+			Expect(mergeYAMLValue("base", "No information found for the combination of standard NIST-800-53 "+
+				"and control AC-2")).To(Equal("base"))
+		})
+	})
+
+	Describe("SummaryTable.Merge", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "merge")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("keeps the edits to the SSP of a control without any information in the YAML", func() {
+			table := getParameterTable("AC-2(a): 60 days")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			roleCell, err := findResponsibleRole(&st)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleCell.setValue("Edited Role")).To(Succeed())
+			base := NewJustification("AC-2")
+			base.ResponsibleRole = "Base Role"
+			base.Parameters["AC-2(a)"] = "30 days"
+			openControlData := loadWorkspace(dir, componentWithParameter("My System", "my_system", "AU-2", "90 days"))
+
+			conflicts, err := st.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(table.Content()).To(ContainSubstring("Responsible Role: Edited Role"))
+			Expect(table.Content()).To(ContainSubstring("Parameter AC-2(a): 60 days"))
+			Expect(table.Content()).NotTo(ContainSubstring("No information found"))
+		})
+
+		It("doesn't conflict with the unchanged values of multiple components in the YAML", func() {
+			table := getParameterTable("AC-2(a): 60 days")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			base := NewJustification("AC-2")
+			base.Parameters["AC-2(a)"] = "30 days, annually"
+			openControlData := loadWorkspace(dir,
+				componentWithParameter("My System", "my_system", "AC-2", "30 days"),
+				componentWithParameter("Other System", "other_system", "AC-2", "annually"))
+
+			conflicts, err := st.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(table.Content()).To(ContainSubstring("Parameter AC-2(a): 60 days"))
+		})
+
+		It("takes the implementation statuses of the YAML when the SSP wasn't changed", func() {
+			table := getStatusTable("Implemented")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			base := NewJustification("AC-2")
+			base.ImplementationStatuses = []implementation.Key{implementation.ImplementedImplementation}
+			openControlData := loadWorkspace(dir, componentWithStatus("planned"))
+
+			conflicts, err := st.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(implementation.ConvertSetToKeys(st.implementationTable.getCheckedImplementationStatuses())).
+				To(ConsistOf(implementation.PlannedImplementation))
+		})
+
+		It("reports the conflict when the SSP and the YAML changed the implementation statuses differently", func() {
+			table := getStatusTable("Partially implemented")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			base := NewJustification("AC-2")
+			base.ImplementationStatuses = []implementation.Key{implementation.ImplementedImplementation}
+			openControlData := loadWorkspace(dir, componentWithStatus("planned"))
+
+			conflicts, err := st.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(HaveLen(1))
+			report := &bytes.Buffer{}
+			conflicts[0].WriteTextTo(report)
+			Expect(report.String()).To(Equal("Control: AC-2. Implementation Status conflicts. " +
+				"Base: \"Implemented\". SSP: \"Partially implemented\". YAML: \"Planned\".\n"))
+			Expect(implementation.ConvertSetToKeys(st.implementationTable.getCheckedImplementationStatuses())).
+				To(ConsistOf(implementation.PartialImplementation))
+		})
+
+		It("fills in the changes to the values of multiple components in the YAML as a single line", func() {
+			table := getParameterTable("AC-2(a): 30 days")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			base := NewJustification("AC-2")
+			base.Parameters["AC-2(a)"] = "30 days"
+			openControlData := loadWorkspace(dir,
+				componentWithParameter("My System", "my_system", "AC-2", "30 days"),
+				componentWithParameter("Other System", "other_system", "AC-2", "annually"))
+
+			conflicts, err := st.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(table.Content()).To(ContainSubstring("Parameter AC-2(a): 30 days, annually"))
+		})
+	})
+
+	Describe("conflictText", func() {
+		It("surrounds the values with the conflict markers", func() {
+			Expect(conflictText("ssp", "yaml")).To(Equal("<<<<<<< SSP ssp ======= yaml >>>>>>> YAML"))
+		})
+	})
+})
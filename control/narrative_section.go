@@ -2,27 +2,31 @@ package control
 
 import (
 	"errors"
-	"regexp"
+	"strings"
 
 	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/common/textdiff"
 	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/reporter"
 	xmlHelper "github.com/opencontrol/fedramp-templater/xml/helper"
 )
 
+const narrativeField = "Narrative"
+
 type narrativeSection struct {
-	row xml.Node
+	row      xml.Node
+	profile  profile.Profile
+	revision *docxHelper.Revision
 }
 
 func (n narrativeSection) parsePart() (key string, err error) {
-	re := regexp.MustCompile(`Part ([a-z])`)
-	content := []byte(n.row.Content())
-	subMatches := re.FindSubmatch(content)
-	if len(subMatches) != 2 {
+	key, found := n.profile.Part(docxHelper.Text(n.row))
+	if !found {
 		err = errors.New("No Parts found.")
-		return
 	}
-	key = string(subMatches[1])
 	return
 }
 
@@ -45,10 +49,87 @@ func (n narrativeSection) GetKey() (key string, err error) {
 	return
 }
 
+// cell returns the cell of the row that contains the narrative.
+func (n narrativeSection) cell() (xml.Node, error) {
+	// the row should have one or two cells; either way, the last one is what contains the narrative
+	return xmlHelper.SearchOne(n.row, `./w:tc[last()]`)
+}
+
+// normalizeNarrative splits the text into lines, collapsing the whitespace within each line and dropping the blank ones.
+// This way, formatting differences between Word and the YAML aren't treated as changes.
+func normalizeNarrative(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// isDefaultValue contains the logic to detect if the input is a default value, i.e. the template's empty cell.
+func (n narrativeSection) isDefaultValue(lines []string) bool {
+	return len(lines) == 0
+}
+
+// getLines returns the normalized lines of the narrative in the cell.
+func (n narrativeSection) getLines(cellNode xml.Node) ([]string, error) {
+	text, err := docxHelper.ParagraphsText(cellNode)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeNarrative(text), nil
+}
+
+// GetValue returns the narrative that is currently in the section/part, with one line per paragraph.
+func (n narrativeSection) GetValue() (string, error) {
+	cellNode, err := n.cell()
+	if err != nil {
+		return "", err
+	}
+	lines, err := n.getLines(cellNode)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Diff compares the narrative in the section/part with the narrative for this control part from the provided data.
+func (n narrativeSection) Diff(data opencontrols.Data, control string) ([]reporter.Reporter, error) {
+	cellNode, err := n.cell()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := n.GetKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sspLines, err := n.getLines(cellNode)
+	if err != nil {
+		return nil, err
+	}
+	yamlLines := normalizeNarrative(data.GetNarrative(control, key))
+	if n.isDefaultValue(sspLines) {
+		return []reporter.Reporter{}, nil
+	}
+
+	diff := textdiff.Unified(sspLines, yamlLines, string(source.SSP), string(source.YAML))
+	if diff == "" {
+		return []reporter.Reporter{}, nil
+	}
+	sspField := field{source: source.SSP, text: strings.Join(sspLines, "\n")}
+	yamlField := field{source: source.YAML, text: strings.Join(yamlLines, "\n")}
+	return []reporter.Reporter{
+		inCell(cellNode, NewNarrativeDiff(control, key, sspField, yamlField, diff)),
+	}, nil
+}
+
 // Fill populates the section/part with the narrative for this control part from the provided data.
 func (n narrativeSection) Fill(data opencontrols.Data, control string) (err error) {
-	// the row should have one or two cells; either way, the last one is what should be filled
-	cellNode, err := xmlHelper.SearchOne(n.row, `./w:tc[last()]`)
+	cellNode, err := n.cell()
 	if err != nil {
 		return
 	}
@@ -58,8 +139,56 @@ func (n narrativeSection) Fill(data opencontrols.Data, control string) (err erro
 		return
 	}
 
-	narrative := data.GetNarrative(control, key)
+	narrative, fill, err := data.ValueToFill(control, fieldName(narrativeField, key), data.GetNarrative(control, key))
+	if err != nil || !fill {
+		return
+	}
+	if n.revision != nil {
+		return n.revision.ReplaceCell(cellNode, narrative)
+	}
 	docxHelper.FillCell(cellNode, narrative)
 
 	return
 }
+
+// Merge applies the change made to the narrative in the YAML since the base SSP to the section/part, unless the
+// section/part was changed as well. If both were changed differently, the conflict is marked in the cell and returned.
+func (n narrativeSection) Merge(data opencontrols.Data, control string, base string) ([]reporter.Reporter, error) {
+	cellNode, err := n.cell()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := n.GetKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sspLines, err := n.getLines(cellNode)
+	if err != nil {
+		return nil, err
+	}
+	narrative := data.GetNarrative(control, key)
+	sspField := field{source: source.SSP, text: strings.Join(sspLines, "\n")}
+	// when the YAML doesn't have any information for the narrative, it didn't change it from the base SSP.
+	yamlField := field{source: source.YAML, text: base}
+	if opencontrols.HasInformation(narrative) {
+		yamlField.text = strings.Join(normalizeNarrative(narrative), "\n")
+	}
+
+	merged, conflict := mergeValues(base, sspField.text, yamlField.text)
+	if conflict {
+		err = docxHelper.FillCell(cellNode, strings.Join(conflictLines(sspField.text, yamlField.text), "\n"))
+		if err != nil {
+			return nil, err
+		}
+		return []reporter.Reporter{
+			inCell(cellNode, NewConflict(control, narrativeField, key, base, sspField, yamlField)),
+		}, nil
+	}
+	if merged != sspField.text {
+		// the YAML changed, so fill it the same way as a new SSP.
+		err = docxHelper.FillCell(cellNode, narrative)
+	}
+	return []reporter.Reporter{}, err
+}
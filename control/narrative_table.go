@@ -2,12 +2,14 @@ package control
 
 import (
 	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
 	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/reporter"
 )
 
-func fillRows(rows []xml.Node, data opencontrols.Data, control string) error {
+func (t *NarrativeTable) fillRows(rows []xml.Node, data opencontrols.Data, control string) error {
 	for _, row := range rows {
-		section := narrativeSection{row}
+		section := t.section(row)
 		err := section.Fill(data, control)
 		if err != nil {
 			return err
@@ -21,30 +23,132 @@ type NarrativeTable struct {
 	table
 }
 
-// NewNarrativeTable creates a NarrativeTable instance.
+// NewNarrativeTable creates a NarrativeTable instance for a table of the v2.1 template.
 func NewNarrativeTable(root xml.Node) NarrativeTable {
-	tbl := table{Root: root}
+	return NewNarrativeTableWithProfile(root, profile.Default())
+}
+
+// NewNarrativeTableWithProfile creates a NarrativeTable instance for a table of the template with the provided profile.
+func NewNarrativeTableWithProfile(root xml.Node, p profile.Profile) NarrativeTable {
+	tbl := table{Root: root, profile: p}
 	return NarrativeTable{tbl}
 }
 
+// section returns the narrative section of the row.
+func (t *NarrativeTable) section(row xml.Node) narrativeSection {
+	return narrativeSection{row: row, profile: t.profile, revision: t.revision}
+}
+
 // SectionRows returns the list of rows which correspond to each "part" of the narrative. Will return a single row when the narrative isn't split into parts.
 func (t *NarrativeTable) SectionRows() ([]xml.Node, error) {
 	// skip the header row
 	return t.table.searchSubtree(`.//w:tr[position() > 1]`)
 }
 
-// Fill inserts the OpenControl data into the table.
+// Fill inserts the OpenControl data into the table, unless the control isn't in the certification of the data.
 func (t *NarrativeTable) Fill(openControlData opencontrols.Data) (err error) {
 	control, err := t.table.controlName()
 	if err != nil {
 		return
 	}
+	if !openControlData.InCertification(control) {
+		return
+	}
 
 	rows, err := t.SectionRows()
 	if err != nil {
 		return
 	}
 
-	fillRows(rows, openControlData, control)
+	err = t.fillRows(rows, openControlData, control)
+	// the rows that can't be parsed are left as they are, but missing data fails with the FailOnMissing policy.
+	if _, missing := err.(opencontrols.MissingDataError); !missing {
+		err = nil
+	}
 	return
 }
+
+// Diff returns the list of diffs between the narrative sections in the table and the OpenControl data.
+func (t *NarrativeTable) Diff(openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	reports := []reporter.Reporter{}
+	control, err := t.table.controlName()
+	if err != nil {
+		return reports, err
+	}
+
+	rows, err := t.SectionRows()
+	if err != nil {
+		return reports, err
+	}
+
+	for _, row := range rows {
+		section := t.section(row)
+		diffReports, err := section.Diff(openControlData, control)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, diffReports...)
+	}
+	return reports, nil
+}
+
+// Extract returns the narratives that are currently filled in the table, keyed by part.
+func (t *NarrativeTable) Extract() (Justification, error) {
+	control, err := t.table.controlName()
+	if err != nil {
+		return Justification{}, err
+	}
+	justification := NewJustification(control)
+
+	rows, err := t.SectionRows()
+	if err != nil {
+		return justification, err
+	}
+
+	for _, row := range rows {
+		section := t.section(row)
+		key, err := section.GetKey()
+		if err != nil {
+			return justification, err
+		}
+		value, err := section.GetValue()
+		if err != nil {
+			return justification, err
+		}
+		if value != "" {
+			justification.Narratives[key] = value
+		}
+	}
+	return justification, nil
+}
+
+// Merge applies the changes made to the narratives in the YAML since the base SSP (with the provided Justifications)
+// to the table, keeping the changes made in the table. The parts that were changed differently in both are marked in
+// the table and returned as conflicts.
+func (t *NarrativeTable) Merge(bases Justifications, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	reports := []reporter.Reporter{}
+	control, err := t.table.controlName()
+	if err != nil {
+		return reports, err
+	}
+	base := bases.Get(control)
+
+	rows, err := t.SectionRows()
+	if err != nil {
+		return reports, err
+	}
+
+	for _, row := range rows {
+		section := t.section(row)
+		key, err := section.GetKey()
+		if err != nil {
+			return reports, err
+		}
+		conflicts, err := section.Merge(openControlData, control, base.Narratives[key])
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, conflicts...)
+	}
+	return reports, nil
+}
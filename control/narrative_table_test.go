@@ -1,8 +1,18 @@
 package control_test
 
 import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
 	. "github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -34,4 +44,275 @@ var _ = Describe("NarrativeTable", func() {
 			Expect(len(sections)).To(Equal(11))
 		})
 	})
+
+	Describe("Fill", func() {
+		It("fills in the narrative of a Rev 5 table", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			// rewrite the header the way that the Rev 5 template does
+			textNodes, err := root.Search(".//w:t[contains(., 'AC-2 (1)')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent("AC-2(1) ")
+			openControlData := fixtures.LoadOpenControlFixture()
+			rev5, err := profile.Get(profile.Rev5)
+			Expect(err).NotTo(HaveOccurred())
+
+			table := NewNarrativeTableWithProfile(root, rev5)
+			err = table.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(root.Content()).To(ContainSubstring("Justification in narrative form for AC-2 (1)"))
+		})
+
+		It("leaves the table of a control that isn't in the certification", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+			certification, err := opencontrols.LoadCertification(
+				filepath.Join(fixtures.OpenControlFixturePath(), "certifications", "LATO.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			openControlData.SetCertification(certification)
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(root.Content()).NotTo(ContainSubstring("Justification in narrative form"))
+		})
+	})
+
+	Describe("Fill with missing data", func() {
+		var (
+			doc   *ssp.Document
+			root  xml.Node
+			table NarrativeTable
+		)
+
+		BeforeEach(func() {
+			doc = fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+			var err error
+			root, err = doc.NarrativeTable("AC-2")
+			Expect(err).NotTo(HaveOccurred())
+			table = NewNarrativeTable(root)
+
+			By("writing a narrative by hand in a part that the YAML doesn't have")
+			rows, err := table.SectionRows()
+			Expect(err).NotTo(HaveOccurred())
+			cells, err := rows[2].Search(`./w:tc[last()]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(helper.FillCell(cells[0], "Hand-written narrative")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			doc.Close()
+		})
+
+		It("leaves the parts without any information as they are by default", func() {
+			err := table.Fill(fixtures.LoadOpenControlFixture())
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(root.Content()).To(ContainSubstring("Justification in narrative form A for AC-2"))
+			Expect(root.Content()).To(ContainSubstring("Hand-written narrative"))
+		})
+
+		It("fills the parts without any information with the placeholder", func() {
+			openControlData := fixtures.LoadOpenControlFixture()
+			openControlData.SetMissingDataPolicy(opencontrols.PlaceholderForMissing, "To be written")
+
+			err := table.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(root.Content()).NotTo(ContainSubstring("Hand-written narrative"))
+			Expect(strings.Count(root.Content(), "To be written")).To(Equal(9))
+		})
+
+		It("fails for the first part without any information", func() {
+			openControlData := fixtures.LoadOpenControlFixture()
+			openControlData.SetMissingDataPolicy(opencontrols.FailOnMissing, "")
+
+			err := table.Fill(openControlData)
+
+			Expect(err).To(Equal(opencontrols.MissingDataError{Control: "AC-2", Field: "Narrative c"}))
+			Expect(root.Content()).To(ContainSubstring("Hand-written narrative"))
+		})
+	})
+
+	Describe("ControlName", func() {
+		It("returns the control of the table", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+
+			table := NewNarrativeTable(root)
+			Expect(table.ControlName()).To(Equal("AC-2 (1)"))
+		})
+	})
+
+	Describe("TrackChanges", func() {
+		It("replaces the narrative with deletions and insertions by the author", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+			textNodes, err := root.Search(".//w:t[contains(., 'Justification in narrative form')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent("Edited justification")
+
+			table.TrackChanges(helper.NewRevision("Reviewer", time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)))
+			err = table.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			content := root.String()
+			Expect(content).To(ContainSubstring(`<w:delText>Edited justification</w:delText>`))
+			Expect(content).To(ContainSubstring(
+				`<w:t xml:space="preserve">Justification in narrative form for AC-2 (1)</w:t>`))
+			Expect(content).To(ContainSubstring(`w:author="Reviewer" w:date="2016-08-01T12:00:00Z"`))
+		})
+	})
+
+	Describe("Diff", func() {
+		It("detects no diff when the narrative matches the YAML", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+
+			diff, err := table.Diff(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+
+		It("reports the line diff for a narrative that was edited in the SSP", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("editing the narrative text in the document")
+			textNodes, err := root.Search(".//w:t[contains(., 'Justification in narrative form')]")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(textNodes).To(HaveLen(1))
+			textNodes[0].SetContent("Edited   justification ")
+
+			diff, err := table.Diff(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(HaveLen(1))
+			report := &bytes.Buffer{}
+			diff[0].WriteTextTo(report)
+			Expect(report.String()).To(Equal("Control: AC-2 (1). Narrative differs between SSP and YAML:\n" +
+				"--- SSP\n+++ YAML\n@@ -1,2 +1,2 @@\n Amazon Elastic Compute Cloud\n" +
+				"-Edited justification\n+Justification in narrative form for AC-2 (1)\n"))
+		})
+	})
+
+	Describe("Merge", func() {
+		It("fills in the YAML when the narrative wasn't changed in the SSP", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			conflicts, err := table.Merge(Justifications{}, openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(root.Content()).To(ContainSubstring("Justification in narrative form for AC-2 (1)"))
+		})
+
+		It("keeps the narrative that was edited in the SSP when the YAML wasn't changed", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+			base, err := table.Extract()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("editing the narrative text in the document")
+			textNodes, err := root.Search(".//w:t[contains(., 'Justification in narrative form')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent("Edited justification")
+
+			conflicts, err := table.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(root.Content()).To(ContainSubstring("Edited justification"))
+			Expect(root.Content()).NotTo(ContainSubstring("Justification in narrative form"))
+		})
+
+		It("leaves the narrative of a control without any information in the YAML", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (3)")
+			Expect(err).NotTo(HaveOccurred())
+			before := root.Content()
+
+			table := NewNarrativeTable(root)
+			conflicts, err := table.Merge(Justifications{}, fixtures.LoadOpenControlFixture())
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(root.Content()).NotTo(ContainSubstring("No information found"))
+			Expect(root.Content()).To(Equal(before))
+		})
+
+		It("marks the conflict when the narrative was changed in both the SSP and the YAML", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			root, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			table := NewNarrativeTable(root)
+			err = table.Fill(openControlData)
+			Expect(err).NotTo(HaveOccurred())
+			textNodes, err := root.Search(".//w:t[contains(., 'Justification in narrative form')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent("Edited justification")
+
+			By("using a base SSP that had a different narrative than the YAML")
+			base := NewJustification("AC-2 (1)")
+			base.Narratives[""] = "Old justification"
+
+			conflicts, err := table.Merge(NewJustifications([]Justification{base}), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(HaveLen(1))
+			report := &bytes.Buffer{}
+			conflicts[0].WriteTextTo(report)
+			Expect(report.String()).To(Equal("Control: AC-2 (1). Narrative conflicts. " +
+				"Base: \"Old justification\". " +
+				"SSP: \"Amazon Elastic Compute Cloud\nEdited justification\". " +
+				"YAML: \"Amazon Elastic Compute Cloud\nJustification in narrative form for AC-2 (1)\".\n"))
+			Expect(root.Content()).To(ContainSubstring("<<<<<<< SSP"))
+			Expect(root.Content()).To(ContainSubstring(">>>>>>> YAML"))
+		})
+	})
 })
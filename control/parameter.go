@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/xml/helper"
 	"gopkg.in/fatih/set.v0"
 )
@@ -14,23 +16,27 @@ import (
 type Parameter struct {
 	parentNode xml.Node
 	textNodes  *[]xml.Node
+	// label is the text at the start of the cell, e.g. "Parameter".
+	label    string
+	revision *docxHelper.Revision
 }
 
 func NewParameter(parentNode xml.Node, textNodes *[]xml.Node) *Parameter {
-    return &Parameter{parentNode: parentNode, textNodes: textNodes}
+    return &Parameter{parentNode: parentNode, textNodes: textNodes, label: profile.Default().Labels.Parameter}
 }
 
 // findParameters looks for the Parameter cell(s) in the control table.
 func findParameters(ct *SummaryTable) (*set.Set, error) {
 	parameterNodeSet := set.New()
-	parameterNodes, err := ct.table.searchSubtree(".//w:tc[starts-with(normalize-space(.), 'Parameter')]")
+	label := ct.profile.Labels.Parameter
+	parameterNodes, err := ct.table.searchSubtree(fmt.Sprintf(".//w:tc[starts-with(normalize-space(.), %s)]", helper.Literal(label)))
 	if (err == nil && len(parameterNodes) >= 1) {
 	    for _, node := range parameterNodes {
 	        childNodes, childErr := helper.SearchSubtree(node, `.//w:t`)
 	        if childErr != nil || len(childNodes) < 1 {
 		        return nil, errors.New("Should not happen, cannot find text nodes.")
 	        }
-			parameterNodeSet.Add(&Parameter{parentNode: node, textNodes: &childNodes})
+			parameterNodeSet.Add(&Parameter{parentNode: node, textNodes: &childNodes, label: label, revision: ct.revision})
         }
 	}
 	return parameterNodeSet, err
@@ -39,22 +45,28 @@ func findParameters(ct *SummaryTable) (*set.Set, error) {
 // parameter is the container for the responsible role cell.
 // getContent returns the full string representation of the content of the cell itself.
 func (r *Parameter) getContent() string {
-	return r.parentNode.Content()
+	return docxHelper.Text(r.parentNode)
 }
 
 // setValue will set the value of the responsible role cell and do any needed formatting.
 // In this case, it will just place the text after ":"
 // If there are other nodes, we don't care about them, zero the content out.
-func (r *Parameter) setValue(value string) {
+// When tracking changes, the text is replaced with a revision instead.
+func (r *Parameter) setValue(value string) error {
     id := r.getId()
+	text := fmt.Sprintf("%s %s: %s", r.label, id, value)
+	if r.revision != nil {
+		return r.revision.ReplaceText(*r.textNodes, text)
+	}
 
 	for idx, node := range *(r.textNodes) {
 		if idx == 0 {
-			node.SetContent(fmt.Sprintf("Parameter %s: %s", id, value))
+			node.SetContent(text)
 		} else {
 			node.SetContent("")
 		}
 	}
+	return nil
 }
 
 // isDefaultValue contains the logic to detect if the input is a default value. This is looking at the extracted
@@ -63,11 +75,25 @@ func (r *Parameter) isDefaultValue(value string) bool {
 	return value == ""
 }
 
+// parameterRegex matches the full string representation of a parameter cell with the label, e.g.
+// `Parameter AC-2(a): value`. The value (and the colon before it) is optional because the template leaves it blank.
+func parameterRegex(label string) *regexp.Regexp {
+	return regexp.MustCompile(`(?s)` + regexp.QuoteMeta(label) + `\s+([^:]+):?(.*)`)
+}
+
+// parseContent splits the full string representation into the ID and the value.
+func (r *Parameter) parseContent() (id string, value string) {
+	subMatches := parameterRegex(r.label).FindStringSubmatch(docxHelper.Text(r.parentNode))
+	if len(subMatches) != 3 {
+		return
+	}
+	return subMatches[1], subMatches[2]
+}
+
 // getId returns the ID from the full string representation.
 // It looks at the text after Parameter and before ":"
 func (r *Parameter) getId() string {
-	re := regexp.MustCompile("Parameter (.+):(.+)")
-	idText := re.FindStringSubmatch(r.parentNode.Content())[1]
+	idText, _ := r.parseContent()
 	idTextNoSpaces := strings.Replace(idText, " ", "", -1)
 	return strings.TrimSpace(idTextNoSpaces)
 }
@@ -75,7 +101,6 @@ func (r *Parameter) getId() string {
 // getValue extracts the unique value from the full string representation.
 // It looks at all the text after ":".
 func (r *Parameter) getValue() string {
-	re := regexp.MustCompile("Parameter (.+):(.+)")
-	parameterText := re.FindStringSubmatch(r.parentNode.Content())[2]
+	_, parameterText := r.parseContent()
 	return strings.TrimSpace(parameterText)
 }
@@ -0,0 +1,47 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func parameterFixture(text string) *Parameter {
+	content := fmt.Sprintf(`<w:tc xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:p><w:r><w:t>%s</w:t></w:r></w:p></w:tc>`, text)
+	doc, err := helper.ParseXML([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	cell := doc.Root()
+	textNodes, err := cell.Search(".//w:t")
+	Expect(err).NotTo(HaveOccurred())
+	return NewParameter(cell, &textNodes)
+}
+
+var _ = Describe("Parameter", func() {
+	Describe("getId", func() {
+		It("returns the ID without spaces", func() {
+			param := parameterFixture("Parameter AC-2 (2)-1: 30 days")
+			Expect(param.getId()).To(Equal("AC-2(2)-1"))
+		})
+
+		It("returns the ID when the template omits the colon", func() {
+			param := parameterFixture("Parameter AC-2(j)")
+			Expect(param.getId()).To(Equal("AC-2(j)"))
+		})
+	})
+
+	Describe("getValue", func() {
+		It("returns the text after the colon", func() {
+			param := parameterFixture("Parameter AC-2(a): shared: group")
+			Expect(param.getValue()).To(Equal("shared: group"))
+		})
+
+		It("returns a default value when the cell is blank", func() {
+			param := parameterFixture("Parameter AC-2(a): ")
+			Expect(param.isDefaultValue(param.getValue())).To(BeTrue())
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package control
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/source"
 	"github.com/opencontrol/fedramp-templater/reporter"
 	"io"
 	"strings"
@@ -10,24 +13,157 @@ import (
 type diffReporter struct {
 	controlName string
 	fieldType   string
+	key         string
 	firstField  field
 	secondField field
 }
 
 // NewDiff creates a new collection of information that can report diff info for a control.
 func NewDiff(controlName, fieldType string, firstField, secondField field) reporter.Reporter {
+	return NewKeyedDiff(controlName, fieldType, "", firstField, secondField)
+}
+
+// NewKeyedDiff creates a new collection of information that can report diff info for a part or parameter of a control.
+func NewKeyedDiff(controlName, fieldType, key string, firstField, secondField field) reporter.Reporter {
+	return newDiffReporter(controlName, fieldType, key, firstField, secondField)
+}
+
+func newDiffReporter(controlName, fieldType, key string, firstField, secondField field) diffReporter {
 	return diffReporter{
 		controlName: strings.TrimSpace(controlName),
 		fieldType:   strings.TrimSpace(fieldType),
+		key:         strings.TrimSpace(key),
 		firstField:  firstField,
 		secondField: secondField,
 	}
 }
 
+// fieldLabel returns the human-readable name of the field, e.g. `Parameter AC-2(a)` or `Narrative Part a`.
+func (r diffReporter) fieldLabel() string {
+	switch {
+	case r.key == "":
+		return r.fieldType
+	case r.fieldType == narrativeField:
+		return fmt.Sprintf("%s Part %s", r.fieldType, r.key)
+	default:
+		return fmt.Sprintf("%s %s", r.fieldType, r.key)
+	}
+}
+
 // WriteTextTo writes diff information for a control to the writer in plain text format.
 func (r diffReporter) WriteTextTo(writer io.Writer) error {
+	fieldLabel := r.fieldLabel()
 	_, err := fmt.Fprintf(writer, "Control: %s. %s in %s: \"%s\". %s in %s: \"%s\".\n",
-		r.controlName, r.fieldType, r.firstField.source, strings.TrimSpace(r.firstField.text),
-		r.fieldType, r.secondField.source, strings.TrimSpace(r.secondField.text))
+		r.controlName, fieldLabel, r.firstField.source, strings.TrimSpace(r.firstField.text),
+		fieldLabel, r.secondField.source, strings.TrimSpace(r.secondField.text))
+	return err
+}
+
+// WriteJSONTo writes diff information for a control to the writer as a single line of JSON.
+func (r diffReporter) WriteJSONTo(writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(r.Diff())
+}
+
+// Diff returns the structured diff information for the control.
+func (r diffReporter) Diff() reporter.Diff {
+	diff := reporter.Diff{
+		Control: r.controlName,
+		Field:   r.fieldType,
+		Key:     r.key,
+	}
+	for _, f := range []field{r.firstField, r.secondField} {
+		switch f.source {
+		case source.SSP:
+			diff.SSPValue = strings.TrimSpace(f.text)
+		case source.YAML:
+			diff.YAMLValue = strings.TrimSpace(f.text)
+		}
+	}
+	switch {
+	case diff.SSPValue == "":
+		diff.Change = reporter.OnlyInYAML
+	case diff.YAMLValue == "":
+		diff.Change = reporter.OnlyInSSP
+	default:
+		diff.Change = reporter.Modified
+	}
+	return diff
+}
+
+type narrativeDiffReporter struct {
+	diffReporter
+	unifiedDiff string
+}
+
+// NewNarrativeDiff creates a new collection of information that can report the line diff of a narrative for a control.
+func NewNarrativeDiff(controlName, key string, firstField, secondField field, unifiedDiff string) reporter.Reporter {
+	return narrativeDiffReporter{
+		diffReporter: newDiffReporter(controlName, narrativeField, key, firstField, secondField),
+		unifiedDiff:  unifiedDiff,
+	}
+}
+
+// WriteTextTo writes the narrative diff for a control to the writer as a unified line diff.
+func (r narrativeDiffReporter) WriteTextTo(writer io.Writer) error {
+	_, err := fmt.Fprintf(writer, "Control: %s. %s differs between %s and %s:\n%s",
+		r.controlName, r.fieldLabel(), r.firstField.source, r.secondField.source, r.unifiedDiff)
 	return err
 }
+
+type conflictReporter struct {
+	diffReporter
+	baseText string
+}
+
+// NewConflict creates a new collection of information that can report a conflict between the changes made to a field
+// of a control in the SSP and in the YAML, since the base SSP.
+func NewConflict(controlName, fieldType, key, baseText string, firstField, secondField field) reporter.Reporter {
+	return conflictReporter{
+		diffReporter: newDiffReporter(controlName, fieldType, key, firstField, secondField),
+		baseText:     baseText,
+	}
+}
+
+// WriteTextTo writes the conflict information for a control to the writer in plain text format.
+func (r conflictReporter) WriteTextTo(writer io.Writer) error {
+	_, err := fmt.Fprintf(writer, "Control: %s. %s conflicts. Base: \"%s\". %s: \"%s\". %s: \"%s\".\n",
+		r.controlName, r.fieldLabel(), strings.TrimSpace(r.baseText),
+		r.firstField.source, strings.TrimSpace(r.firstField.text),
+		r.secondField.source, strings.TrimSpace(r.secondField.text))
+	return err
+}
+
+// WriteJSONTo writes the conflict information for a control to the writer as a single line of JSON.
+func (r conflictReporter) WriteJSONTo(writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(r.Diff())
+}
+
+// Diff returns the structured conflict information for the control.
+func (r conflictReporter) Diff() reporter.Diff {
+	diff := r.diffReporter.Diff()
+	diff.BaseValue = strings.TrimSpace(r.baseText)
+	diff.Change = reporter.Conflict
+	return diff
+}
+
+// CellReporter is a Reporter for a table cell of the SSP.
+type CellReporter interface {
+	reporter.Reporter
+	// Cell returns the table cell that the information is about.
+	Cell() xml.Node
+}
+
+type cellReporter struct {
+	reporter.Reporter
+	cell xml.Node
+}
+
+// Cell returns the table cell that the information is about.
+func (r cellReporter) Cell() xml.Node {
+	return r.cell
+}
+
+// inCell associates the reporter with the table cell that it reports on.
+func inCell(cell xml.Node, r reporter.Reporter) reporter.Reporter {
+	return cellReporter{Reporter: r, cell: cell}
+}
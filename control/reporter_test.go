@@ -31,4 +31,41 @@ var _ = Describe("Reporter", func() {
 			Expect(fakeConsole.String()).To(Equal("Control: control. myfield in SSP: \"sspValue\". myfield in YAML: \"yamlValue\".\n"))
 		})
 	})
+	Describe("WriteJSONTo", func() {
+		It("should write the data as a single line of JSON to the writer", func() {
+			diff := NewKeyedDiff("AC-2", "Parameter", "AC-2(a)", field{source: source.SSP, text: "sspValue "},
+				field{source: source.YAML, text: "yamlValue\n"})
+			fakeConsole := createFakeStdOut()
+			diff.WriteJSONTo(fakeConsole)
+			Expect(fakeConsole.String()).To(Equal(`{"control":"AC-2","field":"Parameter","key":"AC-2(a)",` +
+				`"ssp_value":"sspValue","yaml_value":"yamlValue","change":"modified"}` + "\n"))
+		})
+	})
+	Describe("Diff", func() {
+		It("should detect the values that are only in the YAML", func() {
+			diff := NewDiff("AC-2", "Control Origination", field{source: source.YAML, text: "shared"},
+				field{source: source.SSP, text: ""})
+			Expect(diff.Diff()).To(Equal(reporter.Diff{
+				Control:   "AC-2",
+				Field:     "Control Origination",
+				YAMLValue: "shared",
+				Change:    reporter.OnlyInYAML,
+			}))
+		})
+		It("should detect the values that are only in the SSP", func() {
+			diff := NewDiff("AC-2", "Implementation Status", field{source: source.SSP, text: "Planned"},
+				field{source: source.YAML, text: ""})
+			Expect(diff.Diff().Change).To(Equal(reporter.OnlyInSSP))
+		})
+	})
+	Describe("NewConflict", func() {
+		It("should write the base, SSP and YAML values as JSON", func() {
+			conflict := NewConflict("AC-2", "Responsible Role", "", "baseValue",
+				field{source: source.SSP, text: "sspValue"}, field{source: source.YAML, text: "yamlValue"})
+			fakeConsole := createFakeStdOut()
+			conflict.WriteJSONTo(fakeConsole)
+			Expect(fakeConsole.String()).To(Equal(`{"control":"AC-2","field":"Responsible Role",` +
+				`"ssp_value":"sspValue","yaml_value":"yamlValue","base_value":"baseValue","change":"conflict"}` + "\n"))
+		})
+	})
 })
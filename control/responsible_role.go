@@ -7,48 +7,59 @@ import (
 	"strings"
 
 	"github.com/jbowtie/gokogiri/xml"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/xml/helper"
 )
 
 // findResponsibleRole looks for the Responsible Role cell in the control table.
 func findResponsibleRole(ct *SummaryTable) (*responsibleRole, error) {
-	nodes, err := ct.table.searchSubtree(".//w:tc[starts-with(normalize-space(.), 'Responsible Role')]")
+	label := ct.profile.Labels.ResponsibleRole
+	nodes, err := ct.table.searchSubtree(fmt.Sprintf(".//w:tc[starts-with(normalize-space(.), %s)]", helper.Literal(label)))
 	if err != nil {
 		return nil, err
 	}
 	if len(nodes) != 1 {
-		return nil, errors.New("could not find Responsible Role cell")
+		return nil, fmt.Errorf("could not find %s cell", label)
 	}
 	parentNode := nodes[0]
 	childNodes, err := helper.SearchSubtree(parentNode, `.//w:t`)
 	if err != nil || len(childNodes) < 1 {
 		return nil, errors.New("Should not happen, cannot find text nodes.")
 	}
-	return &responsibleRole{parentNode: parentNode, textNodes: &childNodes}, nil
+	return &responsibleRole{parentNode: parentNode, textNodes: &childNodes, label: label, revision: ct.revision}, nil
 }
 
 // responsibleRole is the container for the responsible role cell.
 type responsibleRole struct {
 	parentNode xml.Node
 	textNodes  *[]xml.Node
+	// label is the text at the start of the cell, e.g. "Responsible Role".
+	label    string
+	revision *docxHelper.Revision
 }
 
 // getContent returns the full string representation of the content of the cell itself.
 func (r *responsibleRole) getContent() string {
-	return r.parentNode.Content()
+	return docxHelper.Text(r.parentNode)
 }
 
 // setValue will set the value of the responsible role cell and do any needed formatting.
 // In this case, it will just place the text after "Responsible Role: "
 // If there are other nodes, we don't care about them, zero the content out.
-func (r *responsibleRole) setValue(value string) {
+// When tracking changes, the text is replaced with a revision instead.
+func (r *responsibleRole) setValue(value string) error {
+	text := fmt.Sprintf("%s: %s", r.label, value)
+	if r.revision != nil {
+		return r.revision.ReplaceText(*r.textNodes, text)
+	}
 	for idx, node := range *(r.textNodes) {
 		if idx == 0 {
-			node.SetContent(fmt.Sprintf("Responsible Role: %s", value))
+			node.SetContent(text)
 		} else {
 			node.SetContent("")
 		}
 	}
+	return nil
 }
 
 // isDefaultValue contains the logic to detect if the input is a default value. This is looking at the extracted
@@ -60,10 +71,10 @@ func (r *responsibleRole) isDefaultValue(value string) bool {
 // getValue extracts the unique value from the full string representation.
 // It looks at all the text after "Responsible Role:".
 func (r *responsibleRole) getValue() string {
-	re := regexp.MustCompile("Responsible Role:?")
+	re := regexp.MustCompile(regexp.QuoteMeta(r.label) + ":?")
 	result := ""
 	// Get all the substrings
-	subStrings := re.Split(r.parentNode.Content(), -1)
+	subStrings := re.Split(docxHelper.Text(r.parentNode), -1)
 	// Go through the substrings and find the first one that is not empty.
 	// (So far has always been the string at index 1)
 	for _, subString := range subStrings {
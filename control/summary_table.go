@@ -1,10 +1,15 @@
 package control
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/jbowtie/gokogiri/xml"
 	"github.com/opencontrol/fedramp-templater/common/origin"
 	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/profile"
 	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/docx"
 	"github.com/opencontrol/fedramp-templater/opencontrols"
 	"github.com/opencontrol/fedramp-templater/reporter"
 	"gopkg.in/fatih/set.v0"
@@ -12,10 +17,24 @@ import (
 
 const (
 	responsibleRoleField    = "Responsible Role"
+	parameterField          = "Parameter"
 	controlOriginationField = "Control Origination"
 	implementationStatusField = "Implementation Status"
 )
 
+// singleLineValue converts the value from the YAML (e.g. the responsible roles of multiple components, one per line) to
+// the way that it's stored in a single-line cell, with the lines separated by commas.
+func singleLineValue(value string) string {
+	lines := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, ", ")
+}
+
 // SummaryTable represents the node in the Word docx XML tree that corresponds to the summary information for a security control.
 type SummaryTable struct {
 	table
@@ -23,9 +42,14 @@ type SummaryTable struct {
 	implementationTable *implementationStatus
 }
 
-// NewSummaryTable creates a SummaryTable instance.
+// NewSummaryTable creates a SummaryTable instance for a table of the v2.1 template.
 func NewSummaryTable(root xml.Node) (SummaryTable, error) {
-	tbl := table{Root: root}
+	return NewSummaryTableWithProfile(root, profile.Default())
+}
+
+// NewSummaryTableWithProfile creates a SummaryTable instance for a table of the template with the provided profile.
+func NewSummaryTableWithProfile(root xml.Node, p profile.Profile) (SummaryTable, error) {
+	tbl := table{Root: root, profile: p}
 	originTable, err := newControlOrigination(&tbl)
 	if err != nil {
 		return SummaryTable{}, err
@@ -47,8 +71,12 @@ func (st *SummaryTable) fillResponsibleRole(openControlData opencontrols.Data, c
 		return
 	}
 
-	roles := openControlData.GetResponsibleRoles(control)
-	roleCell.setValue(roles)
+	roles, fill, err := openControlData.ValueToFill(control, responsibleRoleField,
+		openControlData.GetResponsibleRoles(control))
+	if err != nil || !fill {
+		return
+	}
+	err = roleCell.setValue(singleLineValue(roles))
 	return
 }
 
@@ -60,12 +88,36 @@ func (st *SummaryTable) fillParameters(openControlData opencontrols.Data, contro
 
 	for _, paramCell := range parameters.List() {
 	    paramCell := paramCell.(*Parameter)
-	    yamlParameter := openControlData.GetParameter(control, paramCell.getId())
-	    paramCell.setValue(yamlParameter)
+	    id := paramCell.getId()
+	    yamlParameter, fill, fillErr := openControlData.ValueToFill(control, fieldName(parameterField, id),
+	        openControlData.GetParameter(control, id))
+	    if fillErr != nil {
+	        return fillErr
+	    }
+	    if !fill {
+	        continue
+	    }
+	    err = paramCell.setValue(singleLineValue(yamlParameter))
+	    if err != nil {
+	        return
+	    }
 	}
 	return
 }
 
+// check checks the checkbox, as a tracked change when tracking changes. The template may not have a checkbox for each
+// of the values, in which case there's nothing to check.
+func (st *SummaryTable) check(checkBox *docx.CheckBox) error {
+	if checkBox == nil {
+		return nil
+	}
+	if st.revision != nil {
+		return checkBox.SetCheckMarkWithRevision(true, st.revision)
+	}
+	checkBox.SetCheckMarkTo(true)
+	return nil
+}
+
 func (st *SummaryTable) fillControlOrigination(openControlData opencontrols.Data, control string) (err error) {
 	controlOrigins := openControlData.GetControlOrigins(control)
 	checkedOriginsSet := controlOrigins.GetCheckedOrigins()
@@ -75,7 +127,10 @@ func (st *SummaryTable) fillControlOrigination(openControlData opencontrols.Data
 		if checkedOrigin == origin.NoOrigin {
 			continue
 		}
-		st.originTable.origins[checkedOrigin].SetCheckMarkTo(true)
+		err = st.check(st.originTable.origins[checkedOrigin])
+		if err != nil {
+			return
+		}
 	}
 	return
 }
@@ -89,17 +144,24 @@ func (st *SummaryTable) fillImplementationStatus(openControlData opencontrols.Da
 		if checkedStatus == implementation.NoStatus {
 			continue
 		}
-		st.implementationTable.statuses[checkedStatus].SetCheckMarkTo(true)
+		err = st.check(st.implementationTable.statuses[checkedStatus])
+		if err != nil {
+			return
+		}
 	}
 	return
 }
 
-// Fill inserts the OpenControl justifications into the table. Note this modifies the `table`.
+// Fill inserts the OpenControl justifications into the table. Note this modifies the `table`. The table is left as it
+// is when the control isn't in the certification of the data.
 func (st *SummaryTable) Fill(openControlData opencontrols.Data) (err error) {
 	control, err := st.controlName()
 	if err != nil {
 		return
 	}
+	if !openControlData.InCertification(control) {
+		return
+	}
 	err = st.fillResponsibleRole(openControlData, control)
 	if err != nil {
 		return
@@ -132,7 +194,7 @@ func (st *SummaryTable) diffControlOrigination(control string,
 	yamlControlOrigins := yamlControlOriginationData.GetCheckedOrigins()
 
 	// find the difference of the two sets.
-	controlOriginMap := origin.GetSourceMappings()
+	controlOriginMap := st.profile.OriginMappings()
 	reports := []reporter.Reporter{}
 
 	// find only the origins in the document.
@@ -150,7 +212,7 @@ func (st *SummaryTable) diffControlOrigination(control string,
 	return reports, nil
 }
 
-func (*SummaryTable) createControlOriginsDiffReport(diff set.Interface,
+func (st *SummaryTable) createControlOriginsDiffReport(diff set.Interface,
 	controlOriginSrcMap map[origin.Key]origin.SrcMapping, control string, src source.Source) []reporter.Reporter {
 	reports := []reporter.Reporter{}
 	secondField := field{text: ""}
@@ -168,7 +230,61 @@ func (*SummaryTable) createControlOriginsDiffReport(diff set.Interface,
 			secondField.source = source.SSP
 		}
 		// Get the doc mapping and put it in the doc.
-		reports = append(reports, NewDiff(control, controlOriginationField, firstField, secondField))
+		reports = append(reports, inCell(st.originTable.cell,
+			NewDiff(control, controlOriginationField, firstField, secondField)))
+	}
+	return reports
+}
+
+// diffImplementationStatus computes the diff of the implementation status.
+func (st *SummaryTable) diffImplementationStatus(control string,
+	openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	// find the implementation statuses currently checked in the section in the doc.
+	docImplementationStatuses := st.implementationTable.getCheckedImplementationStatuses()
+
+	// find the implementation statuses noted in the yaml.
+	yamlImplementationStatusData := openControlData.GetImplementationStatuses(control)
+	// find the implementation statuses currently checked in the section in the YAML.
+	yamlImplementationStatuses := yamlImplementationStatusData.GetCheckedImplementationStatuses()
+
+	// find the difference of the two sets.
+	implementationStatusMap := st.profile.ImplementationMappings()
+	reports := []reporter.Reporter{}
+
+	// find only the statuses in the document.
+	onlyInDocStatuses := set.Difference(docImplementationStatuses, yamlImplementationStatuses)
+	// create the diff report for the statuses only in the document.
+	onlyInDocStatusReports := st.createImplementationStatusDiffReport(onlyInDocStatuses, implementationStatusMap, control, source.SSP)
+	reports = append(reports, onlyInDocStatusReports...)
+
+	// find only the statuses in the yaml.
+	onlyInYAMLStatuses := set.Difference(yamlImplementationStatuses, docImplementationStatuses)
+	// create the diff report for the statuses only in the yaml.
+	onlyInYAMLStatusReports := st.createImplementationStatusDiffReport(onlyInYAMLStatuses, implementationStatusMap, control, source.YAML)
+	reports = append(reports, onlyInYAMLStatusReports...)
+
+	return reports, nil
+}
+
+func (st *SummaryTable) createImplementationStatusDiffReport(diff set.Interface,
+	implementationStatusSrcMap map[implementation.Key]implementation.SrcMapping, control string, src source.Source) []reporter.Reporter {
+	reports := []reporter.Reporter{}
+	secondField := field{text: ""}
+	statusKeys := implementation.ConvertSetToKeys(diff)
+	for _, statusKey := range statusKeys {
+		var firstField field
+		switch src {
+		case source.SSP:
+			firstField.text = implementationStatusSrcMap[statusKey][source.SSP]
+			firstField.source = source.SSP
+			secondField.source = source.YAML
+		case source.YAML:
+			firstField.text = implementationStatusSrcMap[statusKey][source.YAML]
+			firstField.source = source.YAML
+			secondField.source = source.SSP
+		}
+		reports = append(reports, inCell(st.implementationTable.cell,
+			NewDiff(control, implementationStatusField, firstField, secondField)))
 	}
 	return reports
 }
@@ -180,17 +296,48 @@ func (st *SummaryTable) diffResponsibleRole(control string, openControlData open
 		return []reporter.Reporter{}, err
 	}
 	yamlField := field{source: source.YAML}
-	yamlField.text = openControlData.GetResponsibleRoles(control)
+	yamlField.text = singleLineValue(openControlData.GetResponsibleRoles(control))
 	sspField := field{source: source.SSP}
 	sspField.text = roleCell.getValue()
 	if roleCell.isDefaultValue(sspField.text) || yamlField.text == sspField.text {
 		return []reporter.Reporter{}, nil
 	}
 	return []reporter.Reporter{
-		NewDiff(control, responsibleRoleField, sspField, yamlField),
+		inCell(roleCell.parentNode, NewDiff(control, responsibleRoleField, sspField, yamlField)),
 	}, nil
 }
 
+// diffParameters computes the diff of each of the parameter cells.
+func (st *SummaryTable) diffParameters(control string, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	reports := []reporter.Reporter{}
+	parameters, err := findParameters(st)
+	if err != nil {
+		return reports, err
+	}
+	// sort the parameters by ID so that the reports are in a stable order.
+	paramCells := []*Parameter{}
+	for _, paramCell := range parameters.List() {
+		paramCells = append(paramCells, paramCell.(*Parameter))
+	}
+	sort.Slice(paramCells, func(i, j int) bool {
+		return paramCells[i].getId() < paramCells[j].getId()
+	})
+
+	for _, paramCell := range paramCells {
+		id := paramCell.getId()
+		sspField := field{source: source.SSP}
+		sspField.text = paramCell.getValue()
+		yamlField := field{source: source.YAML}
+		yamlField.text = singleLineValue(openControlData.GetParameter(control, id))
+		if paramCell.isDefaultValue(sspField.text) || yamlField.text == sspField.text {
+			continue
+		}
+		reports = append(reports, inCell(paramCell.parentNode,
+			NewKeyedDiff(control, parameterField, id, sspField, yamlField)))
+	}
+	return reports, nil
+}
+
 // Diff returns the list of diffs in the control table.
 func (st *SummaryTable) Diff(openControlData opencontrols.Data) ([]reporter.Reporter, error) {
 	reports := []reporter.Reporter{}
@@ -205,11 +352,251 @@ func (st *SummaryTable) Diff(openControlData opencontrols.Data) ([]reporter.Repo
 	}
 	reports = append(reports, diffReports...)
 
+	// Diff the parameters
+	diffReports, err = st.diffParameters(control, openControlData)
+	if err != nil {
+		return reports, err
+	}
+	reports = append(reports, diffReports...)
+
 	// Diff the control origination
 	diffReports, err = st.diffControlOrigination(control, openControlData)
 	if err != nil {
 		return reports, err
 	}
 	reports = append(reports, diffReports...)
+
+	// Diff the implementation status
+	diffReports, err = st.diffImplementationStatus(control, openControlData)
+	if err != nil {
+		return reports, err
+	}
+	reports = append(reports, diffReports...)
+	return reports, nil
+}
+
+// Extract returns the information that is currently filled in the table.
+func (st *SummaryTable) Extract() (Justification, error) {
+	control, err := st.controlName()
+	if err != nil {
+		return Justification{}, err
+	}
+	justification := NewJustification(control)
+
+	roleCell, err := findResponsibleRole(st)
+	if err != nil {
+		return justification, err
+	}
+	if role := roleCell.getValue(); !roleCell.isDefaultValue(role) {
+		justification.ResponsibleRole = role
+	}
+
+	parameters, err := findParameters(st)
+	if err != nil {
+		return justification, err
+	}
+	for _, paramCell := range parameters.List() {
+		paramCell := paramCell.(*Parameter)
+		if value := paramCell.getValue(); !paramCell.isDefaultValue(value) {
+			justification.Parameters[paramCell.getId()] = value
+		}
+	}
+
+	justification.ControlOrigins = origin.ConvertSetToKeys(st.originTable.getCheckedOrigins())
+	sort.Slice(justification.ControlOrigins, func(i, j int) bool {
+		return justification.ControlOrigins[i] < justification.ControlOrigins[j]
+	})
+	justification.ImplementationStatuses = implementation.ConvertSetToKeys(
+		st.implementationTable.getCheckedImplementationStatuses())
+	sort.Slice(justification.ImplementationStatuses, func(i, j int) bool {
+		return justification.ImplementationStatuses[i] < justification.ImplementationStatuses[j]
+	})
+	return justification, nil
+}
+
+// mergeResponsibleRole merges the responsible role cell with the one in the base SSP and the YAML.
+func (st *SummaryTable) mergeResponsibleRole(control string, base Justification,
+	openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	roleCell, err := findResponsibleRole(st)
+	if err != nil {
+		return []reporter.Reporter{}, err
+	}
+	sspField := field{source: source.SSP, text: roleCell.getValue()}
+	yamlField := field{source: source.YAML,
+		text: mergeYAMLValue(base.ResponsibleRole, openControlData.GetResponsibleRoles(control))}
+	merged, conflict := mergeValues(base.ResponsibleRole, sspField.text, yamlField.text)
+	if conflict {
+		err = roleCell.setValue(conflictText(sspField.text, yamlField.text))
+		return []reporter.Reporter{
+			inCell(roleCell.parentNode,
+				NewConflict(control, responsibleRoleField, "", base.ResponsibleRole, sspField, yamlField)),
+		}, err
+	}
+	if merged != sspField.text {
+		err = roleCell.setValue(merged)
+	}
+	return []reporter.Reporter{}, err
+}
+
+// mergeParameters merges each of the parameter cells with the ones in the base SSP and the YAML.
+func (st *SummaryTable) mergeParameters(control string, base Justification,
+	openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	reports := []reporter.Reporter{}
+	parameters, err := findParameters(st)
+	if err != nil {
+		return reports, err
+	}
+	// sort the parameters by ID so that the reports are in a stable order.
+	paramCells := []*Parameter{}
+	for _, paramCell := range parameters.List() {
+		paramCells = append(paramCells, paramCell.(*Parameter))
+	}
+	sort.Slice(paramCells, func(i, j int) bool {
+		return paramCells[i].getId() < paramCells[j].getId()
+	})
+
+	for _, paramCell := range paramCells {
+		id := paramCell.getId()
+		sspField := field{source: source.SSP, text: paramCell.getValue()}
+		yamlField := field{source: source.YAML,
+			text: mergeYAMLValue(base.Parameters[id], openControlData.GetParameter(control, id))}
+		merged, conflict := mergeValues(base.Parameters[id], sspField.text, yamlField.text)
+		if conflict {
+			err = paramCell.setValue(conflictText(sspField.text, yamlField.text))
+			reports = append(reports, inCell(paramCell.parentNode,
+				NewConflict(control, parameterField, id, base.Parameters[id], sspField, yamlField)))
+		} else if merged != sspField.text {
+			err = paramCell.setValue(merged)
+		}
+		if err != nil {
+			return reports, err
+		}
+	}
+	return reports, nil
+}
+
+// checkedOriginsText returns the text of the checked control originations, in the order of the check boxes, so that
+// they can be merged and reported as a single value.
+func (st *SummaryTable) checkedOriginsText(origins set.Interface) string {
+	mappings := st.profile.OriginMappings()
+	keys := origin.ConvertSetToKeys(origins)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	texts := []string{}
+	for _, key := range keys {
+		texts = append(texts, mappings[key][source.SSP])
+	}
+	return strings.Join(texts, ", ")
+}
+
+// mergeControlOrigination merges the checked control originations with the ones in the base SSP and the YAML, as a
+// single value. If both were changed differently, the check boxes are left as they are and the conflict is returned.
+func (st *SummaryTable) mergeControlOrigination(control string, base Justification,
+	openControlData opencontrols.Data) []reporter.Reporter {
+	baseOrigins := set.New()
+	for _, originKey := range base.ControlOrigins {
+		baseOrigins.Add(originKey)
+	}
+	yamlOrigins := openControlData.GetControlOrigins(control).GetCheckedOrigins()
+	if yamlOrigins.IsEmpty() {
+		// the YAML doesn't have any information, so it didn't change them.
+		yamlOrigins = baseOrigins
+	}
+	baseText := st.checkedOriginsText(baseOrigins)
+	sspField := field{source: source.SSP, text: st.checkedOriginsText(st.originTable.getCheckedOrigins())}
+	yamlField := field{source: source.YAML, text: st.checkedOriginsText(yamlOrigins)}
+	merged, conflict := mergeValues(baseText, sspField.text, yamlField.text)
+	if conflict {
+		return []reporter.Reporter{
+			inCell(st.originTable.cell, NewConflict(control, controlOriginationField, "", baseText, sspField, yamlField)),
+		}
+	}
+	if merged == sspField.text {
+		return []reporter.Reporter{}
+	}
+	for originKey, checkBox := range st.originTable.origins {
+		if checkBox != nil && checkBox.IsChecked() != yamlOrigins.Has(originKey) {
+			checkBox.SetCheckMarkTo(yamlOrigins.Has(originKey))
+		}
+	}
+	return []reporter.Reporter{}
+}
+
+// checkedStatusesText returns the text of the checked implementation statuses, in the order of the check boxes, so
+// that they can be merged and reported as a single value.
+func (st *SummaryTable) checkedStatusesText(statuses set.Interface) string {
+	mappings := st.profile.ImplementationMappings()
+	keys := implementation.ConvertSetToKeys(statuses)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	texts := []string{}
+	for _, key := range keys {
+		texts = append(texts, mappings[key][source.SSP])
+	}
+	return strings.Join(texts, ", ")
+}
+
+// mergeImplementationStatus merges the checked implementation statuses with the ones in the base SSP and the YAML, as
+// a single value. If both were changed differently, the check boxes are left as they are and the conflict is returned.
+func (st *SummaryTable) mergeImplementationStatus(control string, base Justification,
+	openControlData opencontrols.Data) []reporter.Reporter {
+	baseStatuses := set.New()
+	for _, statusKey := range base.ImplementationStatuses {
+		baseStatuses.Add(statusKey)
+	}
+	yamlStatuses := openControlData.GetImplementationStatuses(control).GetCheckedImplementationStatuses()
+	if yamlStatuses.IsEmpty() {
+		// the YAML doesn't have any information, so it didn't change them.
+		yamlStatuses = baseStatuses
+	}
+	baseText := st.checkedStatusesText(baseStatuses)
+	sspField := field{source: source.SSP,
+		text: st.checkedStatusesText(st.implementationTable.getCheckedImplementationStatuses())}
+	yamlField := field{source: source.YAML, text: st.checkedStatusesText(yamlStatuses)}
+	merged, conflict := mergeValues(baseText, sspField.text, yamlField.text)
+	if conflict {
+		return []reporter.Reporter{
+			inCell(st.implementationTable.cell,
+				NewConflict(control, implementationStatusField, "", baseText, sspField, yamlField)),
+		}
+	}
+	if merged == sspField.text {
+		return []reporter.Reporter{}
+	}
+	for statusKey, checkBox := range st.implementationTable.statuses {
+		if checkBox != nil && checkBox.IsChecked() != yamlStatuses.Has(statusKey) {
+			checkBox.SetCheckMarkTo(yamlStatuses.Has(statusKey))
+		}
+	}
+	return []reporter.Reporter{}
+}
+
+// Merge applies the changes made in the YAML since the base SSP (with the provided Justifications) to the table,
+// keeping the changes made in the table. The fields that were changed differently in both are marked in the table and
+// returned as conflicts.
+// Note this modifies the `table`.
+func (st *SummaryTable) Merge(bases Justifications, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	reports := []reporter.Reporter{}
+	control, err := st.controlName()
+	if err != nil {
+		return reports, err
+	}
+	base := bases.Get(control)
+	conflicts, err := st.mergeResponsibleRole(control, base, openControlData)
+	if err != nil {
+		return reports, err
+	}
+	reports = append(reports, conflicts...)
+
+	conflicts, err = st.mergeParameters(control, base, openControlData)
+	if err != nil {
+		return reports, err
+	}
+	reports = append(reports, conflicts...)
+
+	reports = append(reports, st.mergeControlOrigination(control, base, openControlData)...)
+	reports = append(reports, st.mergeImplementationStatus(control, base, openControlData)...)
 	return reports, nil
 }
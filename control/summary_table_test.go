@@ -2,12 +2,17 @@ package control
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"text/template"
+	"time"
 
 	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
 	"github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/fixtures"
-	"github.com/opencontrol/fedramp-templater/ssp"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -37,11 +42,84 @@ func docFixture(control string) *xml.XmlDocument {
 func getTable(control string) xml.Node {
 	doc := docFixture(control)
 	// replicate what ssp.Document's SummaryTables() method is doing, except that this source isn't a full Word doc
-	tables, err := doc.Search(ssp.SummaryTablesXPath)
+	tables, err := doc.Search(profile.Default().SummaryTablesXPath)
 	Expect(err).NotTo(HaveOccurred())
 	return tables[0]
 }
 
+// checkBoxParagraph returns the XML of a paragraph with a checkbox content control and the text next to it.
+func checkBoxParagraph(text string, checked bool) string {
+	value, glyph := "0", "☐"
+	if checked {
+		value, glyph = "1", "☒"
+	}
+	return `<w:p><w:sdt><w:sdtPr><w14:checkbox><w14:checked w14:val="` + value + `"/>` +
+		`<w14:checkedState w14:val="2612"/><w14:uncheckedState w14:val="2610"/></w14:checkbox></w:sdtPr>` +
+		`<w:sdtContent><w:r><w:t>` + glyph + `</w:t></w:r></w:sdtContent></w:sdt>` +
+		`<w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+// getImplementationStatusTable returns a table with an Implementation Status cell, with a checkbox for each of the
+// statuses of the v2.1 template, where the provided ones are checked.
+func getImplementationStatusTable(checked ...string) xml.Node {
+	content := `<w:tbl xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"` +
+		` xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Implementation Status (check all that apply):</w:t></w:r></w:p>`
+	for _, status := range []string{"Implemented", "Partially implemented", "Planned"} {
+		isChecked := false
+		for _, checkedStatus := range checked {
+			isChecked = isChecked || checkedStatus == status
+		}
+		content += checkBoxParagraph(status, isChecked)
+	}
+	content += `</w:tc></w:tr></w:tbl>`
+
+	doc, err := helper.ParseXML([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	return doc.Root()
+}
+
+// getParameterTable returns a summary table for AC-2 with a cell for each of the parameters, e.g. `AC-2(a): 90 days`,
+// and control origination and implementation status cells without any checkboxes.
+func getParameterTable(parameters ...string) xml.Node {
+	content := `<w:tbl xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:tr><w:tc><w:p><w:r><w:t>AC-2</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Responsible Role:</w:t></w:r></w:p></w:tc></w:tr>`
+	for _, parameter := range parameters {
+		content += `<w:tr><w:tc><w:p><w:r><w:t>Parameter ` + parameter + `</w:t></w:r></w:p></w:tc></w:tr>`
+	}
+	content += `<w:tr><w:tc><w:p><w:r><w:t>Implementation Status (check all that apply):</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Control Origination (check all that apply):</w:t></w:r></w:p></w:tc></w:tr>` +
+		`</w:tbl>`
+
+	doc, err := helper.ParseXML([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	return doc.Root()
+}
+
+// loadWorkspace writes an OpenControl workspace with the components to the directory, and loads it.
+func loadWorkspace(dir string, components ...opencontrols.Component) opencontrols.Data {
+	certification := opencontrols.NewCertification("FedRAMP", []string{"AC-2"})
+	for _, component := range components {
+		Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+	}
+	// compliance-masonry needs a standards directory, even if it's empty.
+	Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+	data, errors := opencontrols.LoadFrom(dir)
+	Expect(errors).To(BeEmpty())
+	return data
+}
+
+// loadParameterWorkspace writes an OpenControl workspace to the directory with a component that has the parameters
+// for AC-2, and loads it.
+func loadParameterWorkspace(dir string, parameters ...opencontrols.Section) opencontrols.Data {
+	component := opencontrols.NewComponent("My System", "my_system")
+	satisfies := opencontrols.NewSatisfies("AC-2")
+	satisfies.Parameters = parameters
+	component.Satisfies = append(component.Satisfies, satisfies)
+	return loadWorkspace(dir, component)
+}
+
 var _ = Describe("SummaryTable", func() {
 	Describe("Fill", func() {
 		It("fills in the Responsible Role for controls", func() {
@@ -65,6 +143,44 @@ var _ = Describe("SummaryTable", func() {
 
 			Expect(table.Content()).To(ContainSubstring(`Responsible Role: Amazon Elastic Compute Cloud: AWS Staff`))
 		})
+
+		It("leaves the Responsible Role of a control without any information", func() {
+			table := getTable("AC-6")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			err = st.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Content()).NotTo(ContainSubstring(`No information found`))
+		})
+
+		It("fills in the placeholder for the Responsible Role of a control without any information", func() {
+			table := getTable("AC-6")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+			openControlData.SetMissingDataPolicy(opencontrols.PlaceholderForMissing, "")
+
+			err = st.Fill(openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Content()).To(ContainSubstring(`Responsible Role: TBD`))
+		})
+
+		It("fails for the Responsible Role of a control without any information", func() {
+			table := getTable("AC-6")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+			openControlData.SetMissingDataPolicy(opencontrols.FailOnMissing, "")
+
+			err = st.Fill(openControlData)
+
+			Expect(err).To(Equal(opencontrols.MissingDataError{Control: "AC-6", Field: "Responsible Role"}))
+		})
+
 		It("fills in the control origination", func() {
 			table := getTable("AC-2")
 			st, err := NewSummaryTable(table)
@@ -83,6 +199,103 @@ var _ = Describe("SummaryTable", func() {
 			Expect(st.originTable.origins[origin.SharedOrigination].IsChecked()).To(Equal(true))
 		})
 	})
+	Describe("Profile", func() {
+		It("finds the fields with the labels of the profile", func() {
+			table := getTable("AC-2")
+			textNodes, err := table.Search(".//w:t[contains(., 'Responsible Role')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent("Role Owner:")
+			p := profile.Default()
+			p.Labels.ResponsibleRole = "Role Owner"
+			st, err := NewSummaryTableWithProfile(table, p)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			err = st.fillResponsibleRole(openControlData, "AC-2")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Content()).To(ContainSubstring("Role Owner: Amazon Elastic Compute Cloud: AWS Staff"))
+		})
+
+		It("finds the fields with labels that have quotes", func() {
+			table := getTable("AC-2")
+			textNodes, err := table.Search(".//w:t[contains(., 'Responsible Role')]")
+			Expect(err).NotTo(HaveOccurred())
+			textNodes[0].SetContent(`Owner's "Role":`)
+			p := profile.Default()
+			p.Labels.ResponsibleRole = `Owner's "Role"`
+			st, err := NewSummaryTableWithProfile(table, p)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			err = st.fillResponsibleRole(openControlData, "AC-2")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Content()).To(ContainSubstring(`Owner's "Role": Amazon Elastic Compute Cloud: AWS Staff`))
+		})
+	})
+	Describe("TrackChanges", func() {
+		It("fills in the Responsible Role as an insertion by the author", func() {
+			table := getTable("AC-2")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+			date := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+			st.TrackChanges(helper.NewRevision("Reviewer", date))
+
+			err = st.fillResponsibleRole(openControlData, "AC-2")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Content()).To(ContainSubstring(`AWS Staff`))
+			Expect(table.String()).To(ContainSubstring(`<w:t>Responsible Role:</w:t>`))
+			Expect(table.String()).To(ContainSubstring(`<w:ins w:id="0" w:author="Reviewer" w:date="2016-08-01T12:00:00Z">`))
+		})
+
+		It("reads the Responsible Role without the text deleted by the tracked change", func() {
+			table := getTable("AC-2")
+			st, err := NewSummaryTable(table)
+			Expect(err).NotTo(HaveOccurred())
+			roleCell, err := findResponsibleRole(&st)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleCell.setValue("Old Role")).To(Succeed())
+			openControlData := fixtures.LoadOpenControlFixture()
+			st.TrackChanges(helper.NewRevision("Reviewer", time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)))
+
+			err = st.fillResponsibleRole(openControlData, "AC-2")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.String()).To(ContainSubstring(`Old Role</w:delText>`))
+			roleCell, err = findResponsibleRole(&st)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleCell.getValue()).To(Equal(singleLineValue(openControlData.GetResponsibleRoles("AC-2"))))
+		})
+
+		It("checks the implementation status with the symbol as an insertion by the author, leaving its state", func() {
+			tbl := table{Root: getImplementationStatusTable(), profile: profile.Default()}
+			implementationTable, err := newImplementationStatus(&tbl)
+			Expect(err).NotTo(HaveOccurred())
+			st := SummaryTable{table: tbl, implementationTable: implementationTable}
+			openControlData := fixtures.LoadOpenControlFixture()
+			date := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+			st.TrackChanges(helper.NewRevision("Reviewer", date))
+
+			err = st.fillImplementationStatus(openControlData, "AC-2")
+
+			Expect(err).NotTo(HaveOccurred())
+			// Word can't track the state of the checkbox, so only the symbol changes.
+			Expect(st.implementationTable.getCheckedImplementationStatuses().List()).To(BeEmpty())
+			deleted, err := tbl.Root.Search(`.//w:p[contains(., 'Partially')]//w:sdtContent/w:del`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(HaveLen(1))
+			Expect(deleted[0].Attr("author")).To(Equal("Reviewer"))
+			Expect(deleted[0].Content()).To(Equal("☐"))
+			inserted, err := tbl.Root.Search(`.//w:p[contains(., 'Partially')]//w:sdtContent/w:ins`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inserted).To(HaveLen(1))
+			Expect(inserted[0].Attr("author")).To(Equal("Reviewer"))
+			Expect(inserted[0].Content()).To(Equal("☒"))
+		})
+	})
 	Describe("Diff", func() {
 		It("detects no diff when the value of responsible role is empty", func() {
 			Skip("Revisit when we can mock the opencontroldata and really expect no diffs.")
@@ -95,5 +308,86 @@ var _ = Describe("SummaryTable", func() {
 			Expect(diff).To(Equal([]reporter.Reporter{}))
 			Expect(err).To(BeNil())
 		})
+		Context("with parameters", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "parameters")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("detects the parameters that differ from the YAML", func() {
+				st, err := NewSummaryTable(getParameterTable("AC-2(a): 60 days", "AC-2(j): annually"))
+				Expect(err).NotTo(HaveOccurred())
+				openControlData := loadParameterWorkspace(dir,
+					opencontrols.Section{Key: "AC-2(a)", Text: "90 days"},
+					opencontrols.Section{Key: "AC-2(j)", Text: "annually"})
+
+				diff, err := st.Diff(openControlData)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff).To(HaveLen(1))
+				Expect(diff[0].Diff()).To(Equal(reporter.Diff{
+					Control:   "AC-2",
+					Field:     "Parameter",
+					Key:       "AC-2(a)",
+					SSPValue:  "60 days",
+					YAMLValue: "90 days",
+					Change:    reporter.Modified,
+				}))
+			})
+
+			It("detects no diff for the parameters that are blank in the SSP", func() {
+				st, err := NewSummaryTable(getParameterTable("AC-2(a):"))
+				Expect(err).NotTo(HaveOccurred())
+				openControlData := loadParameterWorkspace(dir, opencontrols.Section{Key: "AC-2(a)", Text: "90 days"})
+
+				diff, err := st.Diff(openControlData)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff).To(BeEmpty())
+			})
+		})
+		It("detects the implementation statuses that are only in the SSP or only in the YAML", func() {
+			tbl := table{Root: getImplementationStatusTable("Implemented"), profile: profile.Default()}
+			implementationTable, err := newImplementationStatus(&tbl)
+			Expect(err).NotTo(HaveOccurred())
+			st := SummaryTable{table: tbl, implementationTable: implementationTable}
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			diff, err := st.diffImplementationStatus("AC-2", openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(HaveLen(2))
+			Expect(diff[0].Diff()).To(Equal(reporter.Diff{
+				Control:  "AC-2",
+				Field:    "Implementation Status",
+				SSPValue: "Implemented",
+				Change:   reporter.OnlyInSSP,
+			}))
+			Expect(diff[1].Diff()).To(Equal(reporter.Diff{
+				Control:   "AC-2",
+				Field:     "Implementation Status",
+				YAMLValue: "partial",
+				Change:    reporter.OnlyInYAML,
+			}))
+		})
+		It("detects no diff in the implementation statuses when the SSP and YAML match", func() {
+			tbl := table{Root: getImplementationStatusTable("Partially implemented"), profile: profile.Default()}
+			implementationTable, err := newImplementationStatus(&tbl)
+			Expect(err).NotTo(HaveOccurred())
+			st := SummaryTable{table: tbl, implementationTable: implementationTable}
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			diff, err := st.diffImplementationStatus("AC-2", openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
 	})
 })
@@ -2,14 +2,23 @@ package control
 
 import (
 	"errors"
-	"regexp"
 
 	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/xml/helper"
 )
 
 type table struct {
-	Root xml.Node
+	Root     xml.Node
+	profile  profile.Profile
+	revision *docxHelper.Revision
+}
+
+// TrackChanges makes Fill write its changes to the table as tracked changes of the revision, rather than replacing the
+// content in place.
+func (t *table) TrackChanges(revision *docxHelper.Revision) {
+	t.revision = revision
 }
 
 func (t *table) searchSubtree(xpath string) ([]xml.Node, error) {
@@ -26,7 +35,7 @@ func (t *table) tableHeader() (content string, err error) {
 		return
 	}
 	// we only care about the first match
-	content = nodes[0].Content()
+	content = docxHelper.Text(nodes[0])
 
 	return
 }
@@ -38,10 +47,14 @@ func (t *table) controlName() (name string, err error) {
 	}
 
 	// matches controls and control enhancements, e.g. `AC-2`, `AC-2 (1)`, etc.
-	regex := regexp.MustCompile(`[A-Z]{2}-\d+( +\(\d+\))?`)
-	name = regex.FindString(content)
+	name = t.profile.ControlName(content)
 	if name == "" {
 		err = errors.New("control name not found")
 	}
 	return
 }
+
+// ControlName returns the name of the control of the table, e.g. `AC-2 (1)`.
+func (t *table) ControlName() (string, error) {
+	return t.controlName()
+}
@@ -0,0 +1,13 @@
+package crm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCRM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CRM Suite")
+}
@@ -0,0 +1,114 @@
+package crm
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/xlsx"
+)
+
+// checked is the value of a checked checkbox column.
+const checked = "X"
+
+// implementationStatuses are the implementation status columns of the Control Implementation Summary, in order.
+var implementationStatuses = []implementation.Key{
+	implementation.ImplementedImplementation,
+	implementation.PartialImplementation,
+	implementation.PlannedImplementation,
+	implementation.NotApplicableImplementation,
+}
+
+// controlOrigins are the control origination columns of the Control Implementation Summary, in order.
+var controlOrigins = []origin.Key{
+	origin.ServiceProviderCorporateOrigination,
+	origin.ServiceProviderSystemSpecificOrigination,
+	origin.ServiceProviderHybridOrigination,
+	origin.ConfiguredByCustomerOrigination,
+	origin.ProvidedByCustomerOrigination,
+	origin.SharedOrigination,
+	origin.InheritedOrigination,
+}
+
+// customerOrigins are the control originations that the customer is (partly) responsible for.
+var customerOrigins = []origin.Key{
+	origin.ConfiguredByCustomerOrigination,
+	origin.ProvidedByCustomerOrigination,
+	origin.SharedOrigination,
+}
+
+func summaryHeader() []string {
+	header := []string{"Control ID"}
+	statusMappings := implementation.GetSourceMappings()
+	for _, status := range implementationStatuses {
+		header = append(header, statusMappings[status][source.SSP])
+	}
+	originMappings := origin.GetSourceMappings()
+	for _, controlOrigin := range controlOrigins {
+		header = append(header, originMappings[controlOrigin][source.SSP])
+	}
+	return header
+}
+
+// checkbox returns the value of the column for the checkbox.
+func checkbox(isChecked bool) string {
+	if isChecked {
+		return checked
+	}
+	return ""
+}
+
+// customerResponsibility returns the narrative of each section of the control, prefixed by the key of the section.
+func customerResponsibility(data opencontrols.Data, control string) string {
+	narratives := []string{}
+	for _, key := range data.GetNarrativeKeys(control) {
+		narrative := strings.TrimSpace(data.GetNarrative(control, key))
+		if key != "" {
+			narrative = fmt.Sprintf("Part %s:\n%s", key, narrative)
+		}
+		narratives = append(narratives, narrative)
+	}
+	return strings.Join(narratives, "\n\n")
+}
+
+// WriteXLSXTo writes the Control Implementation Summary (CIS) and Customer Responsibility Matrix (CRM) workbook for
+// the controls of the components to the writer. The CIS has a row for each control with its implementation status and
+// control origination checkboxes. The CRM has a row for each control that the customer is (partly) responsible for,
+// with its narrative.
+func WriteXLSXTo(writer io.Writer, data opencontrols.Data) error {
+	workbook := xlsx.NewWorkbook()
+	summarySheet := workbook.AddSheet("Control Implementation Summary")
+	summarySheet.AddHeader(summaryHeader()...)
+	matrixSheet := workbook.AddSheet("Customer Responsibility Matrix")
+	matrixSheet.AddHeader("Control ID", "Control Origination", "Customer Responsibility")
+
+	originMappings := origin.GetSourceMappings()
+	for _, control := range data.GetControls() {
+		statuses := data.GetImplementationStatuses(control).GetCheckedImplementationStatuses()
+		origins := data.GetControlOrigins(control).GetCheckedOrigins()
+
+		row := []interface{}{control}
+		for _, status := range implementationStatuses {
+			row = append(row, checkbox(statuses.Has(status)))
+		}
+		for _, controlOrigin := range controlOrigins {
+			row = append(row, checkbox(origins.Has(controlOrigin)))
+		}
+		summarySheet.AddRow(row...)
+
+		customerOriginations := []string{}
+		for _, controlOrigin := range customerOrigins {
+			if origins.Has(controlOrigin) {
+				customerOriginations = append(customerOriginations, originMappings[controlOrigin][source.SSP])
+			}
+		}
+		if len(customerOriginations) > 0 {
+			matrixSheet.AddRow(control, strings.Join(customerOriginations, ", "), customerResponsibility(data, control))
+		}
+	}
+	return workbook.Write(writer)
+}
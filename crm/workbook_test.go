@@ -0,0 +1,42 @@
+package crm_test
+
+import (
+	"bytes"
+
+	. "github.com/opencontrol/fedramp-templater/crm"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// readSheets returns the XML of each sheet of the workbook, keyed by the name of its part.
+func readSheets() map[string]string {
+	buf := &bytes.Buffer{}
+	err := WriteXLSXTo(buf, fixtures.LoadOpenControlFixture())
+	Expect(err).NotTo(HaveOccurred())
+
+	return fixtures.ReadParts(buf.Bytes())
+}
+
+var _ = Describe("WriteXLSXTo", func() {
+	It("writes the checkboxes of each control to the Control Implementation Summary", func() {
+		sheetXML := readSheets()["xl/worksheets/sheet1.xml"]
+
+		Expect(sheetXML).To(ContainSubstring(`<c r="C1" s="1" t="inlineStr"><is><t xml:space="preserve">Partially implemented</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="K1" s="1" t="inlineStr"><is><t xml:space="preserve">Shared</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="A2" t="inlineStr"><is><t xml:space="preserve">AC-2</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="B2" t="inlineStr"><is><t xml:space="preserve"></t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="C2" t="inlineStr"><is><t xml:space="preserve">X</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="K2" t="inlineStr"><is><t xml:space="preserve">X</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="A3" t="inlineStr"><is><t xml:space="preserve">AC-2 (1)</t></is></c>`))
+	})
+
+	It("writes the narratives of the shared controls to the Customer Responsibility Matrix", func() {
+		sheetXML := readSheets()["xl/worksheets/sheet2.xml"]
+
+		Expect(sheetXML).To(ContainSubstring(`<c r="B2" t="inlineStr"><is><t xml:space="preserve">Shared</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring("Part a:&#xA;Amazon Elastic Compute Cloud&#xA;Justification in narrative form A for AC-2&#xA;&#xA;Part b:"))
+		Expect(sheetXML).To(ContainSubstring("Justification in narrative form for AC-2 (1)"))
+	})
+})
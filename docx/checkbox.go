@@ -66,18 +66,49 @@ func (c *CheckBox) IsChecked() bool {
 
 // SetCheckMarkTo will set the checkbox state according to the input value.
 func (c *CheckBox) SetCheckMarkTo(value bool) {
-    // First, set checkBoxCheckedChild
+	err := c.setChecked(value)
+	if err != nil {
+		return
+	}
+	symbolNode, symbol, err := c.symbol(value)
+	if err != nil {
+		return
+	}
+	symbolNode.SetContent(symbol)
+}
+
+// SetCheckMarkWithRevision records the change of the symbol shown for the checkbox as a tracked change of the revision.
+// Word can't track the change of the state of the checkbox itself, so the state is left as it is, in order for
+// rejecting the change to leave the checkbox as it was.
+func (c *CheckBox) SetCheckMarkWithRevision(value bool, revision *helper.Revision) error {
+	symbolNode, symbol, err := c.symbol(value)
+	if err != nil {
+		return err
+	}
+	if symbolNode.Content() == symbol {
+		return nil
+	}
+	return revision.ReplaceText([]xml.Node{symbolNode}, symbol)
+}
+
+// setChecked sets the checked state of the checkbox according to the input value.
+func (c *CheckBox) setChecked(value bool) error {
 	checkBoxValue := checkBoxNotCheckedValue
 	if value == true {
 		checkBoxValue = checkBoxCheckedValue
 	}
 	checkedNode, err := getChild(c.checkMark, checkBoxCheckedChild)
 	if err != nil {
-		return
+		return err
 	}
 	checkedNode.AttributeList()[0].SetContent(checkBoxValue)
+	return nil
+}
 
-	// Next, grab the appropriate unicode value to insert
+// symbol returns the text node of the symbol that is shown for the checkbox, along with the symbol for the value.
+// The text deleted by tracked changes isn't a text node, so it's the symbol that is currently shown.
+func (c *CheckBox) symbol(value bool) (xml.Node, string, error) {
+	// grab the appropriate unicode value to insert
 	var unicodeKey string
 	if value == true {
 		unicodeKey = checkBoxCheckedStateChild
@@ -86,21 +117,21 @@ func (c *CheckBox) SetCheckMarkTo(value bool) {
 	}
 	unicodeKeyNode, err := getChild(c.checkMark, unicodeKey)
 	if err != nil {
-		return
+		return nil, "", err
 	}
     searchQuery := fmt.Sprintf(".//%s//w:t", checkBoxUnicodeKey)
 	unicodeNodes, err := c.checkMark.Parent().Parent().Search(searchQuery)
 	if err != nil {
-		return
+		return nil, "", err
 	} else if len(unicodeNodes) != 1 {
-		return
+		return nil, "", fmt.Errorf("Unable to find the check box symbol.")
 	}
 	// Convert hex value (in string format) to proper integer value
 	unicodeKeyIntValue, err := strconv.ParseInt(unicodeKeyNode.Attr(checkBoxAttributeKey), 16, 32)
 	if err != nil {
-		return
+		return nil, "", err
 	}
-	unicodeNodes[0].SetContent(string(unicodeKeyIntValue))
+	return unicodeNodes[0], string(rune(unicodeKeyIntValue)), nil
 }
 
 // GetTextValue will return the corresponding text for the checkbox.
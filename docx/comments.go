@@ -0,0 +1,116 @@
+package docx
+
+import (
+	"fmt"
+
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+	xmlHelper "github.com/opencontrol/fedramp-templater/xml/helper"
+)
+
+const (
+	// wordNamespace is the namespace of the `w:` elements and attributes.
+	wordNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	// CommentsPart is the name of the part that contains the comments of the Word document.
+	CommentsPart             = "word/comments.xml"
+	commentsContentType      = "application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml"
+	commentsRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+)
+
+// emptyComments is the content of the comments part for a Word document that doesn't have any comments yet.
+const emptyComments = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+	`<w:comments xmlns:w="` + wordNamespace + `"></w:comments>`
+
+// Comments represents the comments of a Word document, which are anchored to ranges of the document body.
+type Comments struct {
+	xmlDoc *xml.XmlDocument
+	ids    *helper.IDs
+}
+
+// LoadComments reads the existing comments from the package, if there are any. The IDs of the new comments are taken
+// from the provided ones, so that they can be shared with the other annotations of the document (e.g. the revision
+// marks).
+func LoadComments(pkg *Package, ids *helper.IDs) (*Comments, error) {
+	content, exists := pkg.Part(CommentsPart)
+	if !exists {
+		content = []byte(emptyComments)
+	}
+	xmlDoc, err := helper.ParseXML(content)
+	if err != nil {
+		return nil, err
+	}
+	// new comments need IDs that aren't used by the existing ones.
+	err = ids.Skip(xmlDoc)
+	if err != nil {
+		xmlDoc.Free()
+		return nil, err
+	}
+	return &Comments{xmlDoc: xmlDoc, ids: ids}, nil
+}
+
+// markRange surrounds the content of the paragraphs within the node (e.g. a table cell) with the range of the comment
+// and adds the reference to the comment at the end.
+func markRange(node xml.Node, id int) error {
+	paragraphs, err := xmlHelper.SearchSubtree(node, `.//w:p`)
+	if err != nil {
+		return err
+	}
+	if len(paragraphs) == 0 {
+		return fmt.Errorf("no paragraphs found to comment on")
+	}
+
+	// the paragraph properties have to stay the first element of the paragraph.
+	start := fmt.Sprintf(`<w:commentRangeStart w:id="%d"/>`, id)
+	firstParagraph := paragraphs[0]
+	children, err := xmlHelper.SearchSubtree(firstParagraph, `./*[not(self::w:pPr)]`)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		err = children[0].AddPreviousSibling(start)
+	} else {
+		err = firstParagraph.AddChild(start)
+	}
+	if err != nil {
+		return err
+	}
+
+	lastParagraph := paragraphs[len(paragraphs)-1]
+	return lastParagraph.AddChild(fmt.Sprintf(`<w:commentRangeEnd w:id="%d"/>`+
+		`<w:r><w:commentReference w:id="%d"/></w:r>`, id, id))
+}
+
+// Add adds a comment with the provided text to the node (e.g. a table cell) of the document body.
+// Each line of the text is a separate paragraph in the comment.
+func (c *Comments) Add(node xml.Node, author, text string) error {
+	id := c.ids.Next()
+	err := markRange(node, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.xmlDoc.Root().AddChild(fmt.Sprintf(`<w:comment w:id="%d"></w:comment>`, id))
+	if err != nil {
+		return err
+	}
+	comment := c.xmlDoc.Root().LastChild()
+	// setting the attribute escapes the author.
+	comment.SetNsAttr(wordNamespace, "author", author)
+	return helper.AddMultiLineContent(comment, text)
+}
+
+// Save writes the comments to the package, registering the comments part if it is new.
+func (c *Comments) Save(pkg *Package) error {
+	pkg.SetPart(CommentsPart, []byte(c.xmlDoc.String()))
+	err := pkg.AddContentType(CommentsPart, commentsContentType)
+	if err != nil {
+		return err
+	}
+	// the relationship target is relative to the `word/` directory.
+	return pkg.AddDocumentRelationship(commentsRelationshipType, "comments.xml")
+}
+
+// Free releases the underlying resources.
+func (c *Comments) Free() {
+	c.xmlDoc.Free()
+}
@@ -0,0 +1,100 @@
+package docx_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jbowtie/gokogiri/xml"
+	. "github.com/opencontrol/fedramp-templater/docx"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const existingComments = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+	`<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:comment w:id="0" w:author="Reviewer"><w:p><w:r><w:t>First</w:t></w:r></w:p></w:comment>` +
+	`<w:comment w:id="4" w:author="Reviewer"><w:p><w:r><w:t>Second</w:t></w:r></w:p></w:comment>` +
+	`</w:comments>`
+
+// documentCell returns the table cell of the document of the package.
+func documentCell(pkg *Package) (*xml.XmlDocument, xml.Node) {
+	xmlDoc, err := helper.ParseXML([]byte(partContent(pkg, DocumentPart)))
+	Expect(err).NotTo(HaveOccurred())
+	cells, err := xmlDoc.Search("//w:tc")
+	Expect(err).NotTo(HaveOccurred())
+	return xmlDoc, cells[0]
+}
+
+var _ = Describe("Comments", func() {
+	It("adds the comments part to a package without any comments", func() {
+		pkg := newPackage()
+		xmlDoc, cell := documentCell(pkg)
+		defer xmlDoc.Free()
+		comments, err := LoadComments(pkg, &helper.IDs{})
+		Expect(err).NotTo(HaveOccurred())
+		defer comments.Free()
+
+		Expect(comments.Add(cell, "fedramp-templater", "Differs from the YAML")).To(Succeed())
+		Expect(comments.Save(pkg)).To(Succeed())
+
+		Expect(cell.String()).To(ContainSubstring(`<w:commentRangeStart w:id="0"/>`))
+		content := partContent(pkg, CommentsPart)
+		Expect(content).To(ContainSubstring(`<w:comment w:id="0" w:author="fedramp-templater">`))
+		Expect(content).To(ContainSubstring(`<w:t>Differs from the YAML</w:t>`))
+		Expect(partContent(pkg, "[Content_Types].xml")).To(ContainSubstring(`PartName="/word/comments.xml"`))
+		Expect(partContent(pkg, "word/_rels/document.xml.rels")).To(ContainSubstring(`Target="comments.xml"`))
+	})
+
+	It("continues the IDs of the existing comments", func() {
+		pkg := newPackage()
+		pkg.SetPart(CommentsPart, []byte(existingComments))
+		xmlDoc, cell := documentCell(pkg)
+		defer xmlDoc.Free()
+		comments, err := LoadComments(pkg, &helper.IDs{})
+		Expect(err).NotTo(HaveOccurred())
+		defer comments.Free()
+
+		Expect(comments.Add(cell, "fedramp-templater", "Differs from the YAML")).To(Succeed())
+		Expect(comments.Save(pkg)).To(Succeed())
+
+		Expect(cell.String()).To(ContainSubstring(`<w:commentRangeStart w:id="5"/>`))
+		content := partContent(pkg, CommentsPart)
+		Expect(content).To(ContainSubstring(`<w:comment w:id="4" w:author="Reviewer">`))
+		Expect(content).To(ContainSubstring(`<w:comment w:id="5" w:author="fedramp-templater">`))
+	})
+
+	It("shares the IDs with the revision marks", func() {
+		pkg := newPackage()
+		xmlDoc, cell := documentCell(pkg)
+		defer xmlDoc.Free()
+		ids := &helper.IDs{}
+		revision := helper.NewRevision("fedramp-templater", time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC))
+		revision.UseIDs(ids)
+		comments, err := LoadComments(pkg, ids)
+		Expect(err).NotTo(HaveOccurred())
+		defer comments.Free()
+
+		Expect(revision.ReplaceCell(cell, "AWS Staff")).To(Succeed())
+		Expect(comments.Add(cell, "fedramp-templater", "Differs from the YAML")).To(Succeed())
+
+		Expect(cell.String()).To(ContainSubstring(`<w:del w:id="0"`))
+		Expect(cell.String()).To(ContainSubstring(`<w:ins w:id="1"`))
+		Expect(cell.String()).To(ContainSubstring(`<w:commentRangeStart w:id="2"/>`))
+	})
+
+	It("doesn't register the comments part again when saving it twice", func() {
+		pkg := newPackage()
+		comments, err := LoadComments(pkg, &helper.IDs{})
+		Expect(err).NotTo(HaveOccurred())
+		defer comments.Free()
+
+		Expect(comments.Save(pkg)).To(Succeed())
+		Expect(comments.Save(pkg)).To(Succeed())
+
+		Expect(partContent(pkg, "[Content_Types].xml")).To(ContainSubstring(`PartName="/word/comments.xml"`))
+		Expect(strings.Count(partContent(pkg, "[Content_Types].xml"), `PartName="/word/comments.xml"`)).To(Equal(1))
+		Expect(strings.Count(partContent(pkg, "word/_rels/document.xml.rels"), `Target="comments.xml"`)).To(Equal(1))
+	})
+})
@@ -5,9 +5,11 @@ import (
 
 	"github.com/jbowtie/gokogiri"
 	"github.com/jbowtie/gokogiri/xml"
-	"github.com/opencontrol/doc-template/docx"
 )
 
+// xmlNamespace is the namespace of the `xml:` attributes, e.g. `xml:space`.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
 // ParseXML converts the XML text to a structure.
 func ParseXML(content []byte) (xmlDoc *xml.XmlDocument, err error) {
 	xmlDoc, err = gokogiri.ParseXml(content)
@@ -20,14 +22,6 @@ func ParseXML(content []byte) (xmlDoc *xml.XmlDocument, err error) {
 	return
 }
 
-// GenerateXML gives the underlying XML document for the provided Word document.
-func GenerateXML(wordDoc *docx.Docx) (xmlDoc *xml.XmlDocument, err error) {
-	content := wordDoc.GetContent()
-	// http://stackoverflow.com/a/28261008/358804
-	bytes := []byte(content)
-	return ParseXML(bytes)
-}
-
 // FillParagraph inserts the given content into the provided docx XML paragraph node. Note that newlines aren't respected - you'll need to create a new paragraph node for each.
 func FillParagraph(paragraph xml.Node, content string) (err error) {
 	// this seems to be the easiest way to create child notes
@@ -68,6 +62,51 @@ func FillCell(cell xml.Node, content string) error {
 	return AddMultiLineContent(cell, content)
 }
 
+// SetCellText replaces the text of the provided docx XML table cell node with the content, keeping the cell and
+// paragraph properties and the formatting of the first run. Note that newlines aren't respected.
+func SetCellText(cell xml.Node, content string) error {
+	textNodes, err := cell.Search(".//w:t")
+	if err != nil {
+		return err
+	}
+	if len(textNodes) == 0 {
+		paragraphs, err := cell.Search("./w:p")
+		if err != nil {
+			return err
+		}
+		if len(paragraphs) == 0 {
+			return AddMultiLineContent(cell, content)
+		}
+		err = paragraphs[0].AddChild(`<w:r><w:t></w:t></w:r>`)
+		if err != nil {
+			return err
+		}
+		textNodes = []xml.Node{paragraphs[0].LastChild().FirstChild()}
+	}
+	for idx, textNode := range textNodes {
+		if idx == 0 {
+			textNode.SetContent(content)
+		} else {
+			textNode.SetContent("")
+		}
+	}
+	return nil
+}
+
+// Text returns the text within the provided docx XML node. Unlike Content, it leaves out the text that tracked changes
+// deleted (`w:delText`) and the field codes.
+func Text(node xml.Node) string {
+	textNodes, err := node.Search(".//w:t[not(ancestor::w:del)]")
+	if err != nil {
+		return ""
+	}
+	text := ""
+	for _, textNode := range textNodes {
+		text += textNode.Content()
+	}
+	return text
+}
+
 // ConcatTextNodes will concatenate the text from an array of text nodes and trim any whitespace from the final result.
 func ConcatTextNodes(textNodes []xml.Node) string {
 	result := ""
@@ -76,3 +115,74 @@ func ConcatTextNodes(textNodes []xml.Node) string {
 	}
 	return strings.TrimSpace(result)
 }
+
+// ParagraphsText returns the text of each paragraph within the provided docx XML node, joined by newlines.
+func ParagraphsText(node xml.Node) (string, error) {
+	paragraphs, err := node.Search(".//w:p")
+	if err != nil {
+		return "", err
+	}
+	lines := []string{}
+	for _, paragraph := range paragraphs {
+		textNodes, err := paragraph.Search(".//w:t")
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, ConcatTextNodes(textNodes))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ReplacePlaceholder replaces each occurrence of the placeholder in the text of the provided docx XML paragraph node
+// with the value, and returns the number of replacements. The placeholder may be split across runs (e.g. if Word
+// spell-checked part of it), in which case the value goes in the first one and the formatting of the rest is kept.
+func ReplacePlaceholder(paragraph xml.Node, placeholder, value string) (int, error) {
+	textNodes, err := paragraph.Search(".//w:t")
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	searchFrom := 0
+	for {
+		contents := []string{}
+		for _, textNode := range textNodes {
+			contents = append(contents, textNode.Content())
+		}
+		text := strings.Join(contents, "")
+		idx := strings.Index(text[searchFrom:], placeholder)
+		if idx < 0 {
+			return count, nil
+		}
+		start := searchFrom + idx
+		end := start + len(placeholder)
+
+		// replace the part of each text node that is within the placeholder.
+		offset := 0
+		replaced := false
+		for i, textNode := range textNodes {
+			nodeStart := offset
+			offset += len(contents[i])
+			if offset <= start || nodeStart >= end {
+				continue
+			}
+			from := start - nodeStart
+			if from < 0 {
+				from = 0
+			}
+			to := end - nodeStart
+			if to > len(contents[i]) {
+				to = len(contents[i])
+			}
+			content := contents[i][:from]
+			if !replaced {
+				content += value
+				replaced = true
+			}
+			textNode.SetContent(content + contents[i][to:])
+			// keep the spaces around the value.
+			textNode.SetNsAttr(xmlNamespace, "space", "preserve")
+		}
+		count++
+		searchFrom = start + len(value)
+	}
+}
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"strconv"
+
+	"github.com/jbowtie/gokogiri/xml"
+)
+
+// IDs hands out the `w:id` values of the annotations of a Word document (e.g. the revision marks and the comments),
+// which have to be unique within it. The zero value starts at 0.
+type IDs struct {
+	next int
+}
+
+// Skip makes sure that the IDs that are handed out are higher than the ones that are already used in the XML document.
+func (ids *IDs) Skip(xmlDoc *xml.XmlDocument) error {
+	attributes, err := xmlDoc.Search("//@w:id")
+	if err != nil {
+		return err
+	}
+	for _, attribute := range attributes {
+		id, err := strconv.Atoi(attribute.Content())
+		if err == nil && id >= ids.next {
+			ids.next = id + 1
+		}
+	}
+	return nil
+}
+
+// Next returns an unused ID.
+func (ids *IDs) Next() int {
+	id := ids.next
+	ids.next++
+	return id
+}
@@ -0,0 +1,206 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jbowtie/gokogiri/xml"
+)
+
+// revisionDateFormat is the format of the dates of the revisions, e.g. `2016-08-01T12:00:00Z`.
+const revisionDateFormat = "2006-01-02T15:04:05Z"
+
+// Revision writes changes to the content of a Word document as tracked changes (`w:ins`/`w:del` revision marks), so
+// that each one can be accepted or rejected in Word.
+type Revision struct {
+	Author string
+	Date   time.Time
+	ids    *IDs
+}
+
+// NewRevision creates a Revision for the changes by the author at the date. Its IDs start at 0, see UseIDs to continue
+// the ones of a document.
+func NewRevision(author string, date time.Time) *Revision {
+	return &Revision{Author: author, Date: date, ids: &IDs{}}
+}
+
+// UseIDs makes the revision take the IDs of its revision marks from the provided ones, e.g. the IDs of the document
+// that also hand out the IDs of its comments.
+func (r *Revision) UseIDs(ids *IDs) {
+	r.ids = ids
+}
+
+// attributes returns the attributes of the next revision mark, with a unique ID.
+func (r *Revision) attributes() string {
+	id := r.ids.Next()
+	author := strings.NewReplacer("&", "&amp;", `"`, "&quot;", "<", "&lt;").Replace(r.Author)
+	return fmt.Sprintf(`w:id="%d" w:author="%s" w:date="%s"`, id, author, r.Date.UTC().Format(revisionDateFormat))
+}
+
+// insertedRun returns the XML of an inserted run with the text and run properties.
+func (r *Revision) insertedRun(text, properties string) string {
+	escaped := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+	return fmt.Sprintf(`<w:ins %s><w:r>%s<w:t xml:space="preserve">%s</w:t></w:r></w:ins>`,
+		r.attributes(), properties, escaped)
+}
+
+// deleteRun marks the run as deleted, so that its text is shown as struck through.
+func (r *Revision) deleteRun(run xml.Node) error {
+	textNodes, err := run.Search(`./w:t`)
+	if err != nil {
+		return err
+	}
+	for _, textNode := range textNodes {
+		textNode.SetName("delText")
+	}
+	return run.Wrap(fmt.Sprintf(`<w:del %s></w:del>`, r.attributes()))
+}
+
+// runProperties returns the XML of the properties of the run, so that inserted text can have the same formatting.
+func runProperties(run xml.Node) string {
+	properties, err := run.Search(`./w:rPr`)
+	if err != nil || len(properties) == 0 {
+		return ""
+	}
+	return properties[0].String()
+}
+
+// ReplaceText replaces the text of the provided text nodes (e.g. the ones in a Responsible Role cell) with the text.
+// The leading text nodes that stay the same are kept, and the rest of their runs are marked as deleted.
+func (r *Revision) ReplaceText(textNodes []xml.Node, text string) error {
+	if len(textNodes) == 0 {
+		return fmt.Errorf("no text nodes to replace")
+	}
+	kept := 0
+	keptText := ""
+	for _, textNode := range textNodes {
+		if !strings.HasPrefix(text, keptText+textNode.Content()) {
+			break
+		}
+		keptText += textNode.Content()
+		kept++
+	}
+
+	// the inserted text goes after the last run, whether it is kept or deleted.
+	lastRun := textNodes[len(textNodes)-1].Parent()
+	properties := runProperties(lastRun)
+	for _, textNode := range textNodes[kept:] {
+		run := textNode.Parent()
+		// the run may already be deleted because of another text node in it.
+		if textNode.Content() == "" || run.Parent().Name() == "del" {
+			continue
+		}
+		err := r.deleteRun(run)
+		if err != nil {
+			return err
+		}
+	}
+
+	insertedText := strings.TrimPrefix(text, keptText)
+	if insertedText == "" {
+		return nil
+	}
+	anchor := lastRun
+	if lastRun.Parent().Name() == "del" {
+		anchor = lastRun.Parent()
+	}
+	return anchor.AddNextSibling(r.insertedRun(insertedText, properties))
+}
+
+// prependChild adds the XML as the first child of the node.
+func prependChild(node xml.Node, data string) error {
+	firstChild := node.FirstChild()
+	if firstChild == nil {
+		return node.AddChild(data)
+	}
+	return firstChild.AddPreviousSibling(data)
+}
+
+// markParagraph marks the paragraph mark (i.e. the end of the paragraph) as inserted or deleted.
+func (r *Revision) markParagraph(paragraph xml.Node, mark string) error {
+	properties, err := paragraph.Search(`./w:pPr`)
+	if err != nil {
+		return err
+	}
+	if len(properties) == 0 {
+		err = prependChild(paragraph, `<w:pPr></w:pPr>`)
+		if err != nil {
+			return err
+		}
+		properties, err = paragraph.Search(`./w:pPr`)
+		if err != nil {
+			return err
+		}
+	}
+	runProperties, err := properties[0].Search(`./w:rPr`)
+	if err != nil {
+		return err
+	}
+	if len(runProperties) == 0 {
+		return properties[0].AddChild(fmt.Sprintf(`<w:rPr><w:%s %s/></w:rPr>`, mark, r.attributes()))
+	}
+	// the revision mark has to be the first of the run properties.
+	return prependChild(runProperties[0], fmt.Sprintf(`<w:%s %s/>`, mark, r.attributes()))
+}
+
+// ReplaceCell replaces the content of the provided docx XML table cell node with the content, one paragraph per line.
+// All of the existing paragraphs are marked as deleted, and the new ones as inserted.
+func (r *Revision) ReplaceCell(cell xml.Node, content string) error {
+	paragraphs, err := cell.Search(`./w:p`)
+	if err != nil {
+		return err
+	}
+	if len(paragraphs) == 0 {
+		err = cell.AddChild(`<w:p></w:p>`)
+		if err != nil {
+			return err
+		}
+		paragraphs = []xml.Node{cell.LastChild()}
+	}
+	current, err := ParagraphsText(cell)
+	if err != nil || current == content {
+		return err
+	}
+
+	for _, paragraph := range paragraphs {
+		runs, err := paragraph.Search(`.//w:r[w:t]`)
+		if err != nil {
+			return err
+		}
+		for _, run := range runs {
+			err = r.deleteRun(run)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// the deleted paragraph marks merge the existing paragraphs into the last one, which keeps its mark so that the
+	// cell still ends with a paragraph.
+	for _, paragraph := range paragraphs[:len(paragraphs)-1] {
+		err = r.markParagraph(paragraph, "del")
+		if err != nil {
+			return err
+		}
+	}
+
+	// all but the last line are new paragraphs, before the last existing one.
+	lines := strings.Split(content, "\n")
+	lastParagraph := paragraphs[len(paragraphs)-1]
+	for _, line := range lines[:len(lines)-1] {
+		err = lastParagraph.AddPreviousSibling(`<w:p></w:p>`)
+		if err != nil {
+			return err
+		}
+		paragraph := lastParagraph.PreviousSibling()
+		err = r.markParagraph(paragraph, "ins")
+		if err != nil {
+			return err
+		}
+		err = paragraph.AddChild(r.insertedRun(line, ""))
+		if err != nil {
+			return err
+		}
+	}
+	return lastParagraph.AddChild(r.insertedRun(lines[len(lines)-1], ""))
+}
@@ -0,0 +1,171 @@
+package docx
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DocumentPart is the name of the part that contains the body of the Word document.
+	DocumentPart = "word/document.xml"
+	// contentTypesPart lists the content type of each part in the package.
+	contentTypesPart = "[Content_Types].xml"
+	// documentRelsPart lists the parts that the body of the Word document refers to.
+	documentRelsPart = "word/_rels/document.xml.rels"
+	// packageRelsPart lists the parts that the package refers to, e.g. the body and the properties.
+	packageRelsPart = "_rels/.rels"
+)
+
+// relationshipIDRegex matches the relationship IDs, e.g. `Id="rId12"`.
+var relationshipIDRegex = regexp.MustCompile(`Id="rId(\d+)"`)
+
+// Package is the zip archive of a Word document, which is made up of parts, e.g. `word/document.xml`.
+type Package struct {
+	// names is the list of parts, in the order that they are written.
+	names []string
+	parts map[string][]byte
+}
+
+// OpenPackage reads all of the parts of the Word document at the provided path.
+func OpenPackage(path string) (*Package, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return readPackage(reader.File)
+}
+
+// ReadPackage reads all of the parts of the Word document from the reader, which has the provided size in bytes, e.g.
+// a document that is held in memory.
+func ReadPackage(r io.ReaderAt, size int64) (*Package, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return readPackage(reader.File)
+}
+
+func readPackage(files []*zip.File) (*Package, error) {
+	pkg := &Package{parts: map[string][]byte{}}
+	for _, file := range files {
+		partReader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(partReader)
+		partReader.Close()
+		if err != nil {
+			return nil, err
+		}
+		pkg.SetPart(file.Name, content)
+	}
+	if _, exists := pkg.parts[DocumentPart]; !exists {
+		return nil, errors.New("document.xml file not found")
+	}
+	return pkg, nil
+}
+
+// Part returns the content of the part with the provided name, and whether the part exists.
+func (p *Package) Part(name string) ([]byte, bool) {
+	content, exists := p.parts[name]
+	return content, exists
+}
+
+// SetPart replaces the content of the part with the provided name, adding the part if it doesn't exist yet.
+// New parts also need a content type (see AddContentType) and usually a relationship (see AddDocumentRelationship).
+func (p *Package) SetPart(name string, content []byte) {
+	if _, exists := p.parts[name]; !exists {
+		p.names = append(p.names, name)
+	}
+	p.parts[name] = content
+}
+
+// insertBefore inserts the XML element into the part, before the provided closing tag of the root element.
+func (p *Package) insertBefore(name, closingTag, element string) error {
+	content, exists := p.parts[name]
+	if !exists {
+		return fmt.Errorf("%s not found", name)
+	}
+	idx := strings.LastIndex(string(content), closingTag)
+	if idx < 0 {
+		return fmt.Errorf("%s not found in %s", closingTag, name)
+	}
+	p.parts[name] = []byte(string(content[:idx]) + element + string(content[idx:]))
+	return nil
+}
+
+// AddContentType registers the content type of the part with the provided name, unless it is already registered.
+func (p *Package) AddContentType(name, contentType string) error {
+	content, _ := p.Part(contentTypesPart)
+	partName := fmt.Sprintf(`PartName="/%s"`, name)
+	if strings.Contains(string(content), partName) {
+		return nil
+	}
+	override := fmt.Sprintf(`<Override %s ContentType="%s"/>`, partName, contentType)
+	return p.insertBefore(contentTypesPart, "</Types>", override)
+}
+
+// AddDocumentRelationship adds a relationship of the provided type from the body of the Word document to the target
+// part, unless it already exists. The target is relative to the `word/` directory.
+func (p *Package) AddDocumentRelationship(relationshipType, target string) error {
+	return p.addRelationship(documentRelsPart, relationshipType, target)
+}
+
+// AddPackageRelationship adds a relationship of the provided type from the package to the target part (e.g. the
+// document properties), unless it already exists.
+func (p *Package) AddPackageRelationship(relationshipType, target string) error {
+	return p.addRelationship(packageRelsPart, relationshipType, target)
+}
+
+// addRelationship adds a relationship to the relationships part, unless it already exists.
+func (p *Package) addRelationship(relsPart, relationshipType, target string) error {
+	content, _ := p.Part(relsPart)
+	if strings.Contains(string(content), fmt.Sprintf(`Target="%s"`, target)) {
+		return nil
+	}
+	// find an unused ID.
+	maxID := 0
+	for _, subMatches := range relationshipIDRegex.FindAllStringSubmatch(string(content), -1) {
+		id, err := strconv.Atoi(subMatches[1])
+		if err == nil && id > maxID {
+			maxID = id
+		}
+	}
+	relationship := fmt.Sprintf(`<Relationship Id="rId%d" Type="%s" Target="%s"/>`, maxID+1, relationshipType, target)
+	return p.insertBefore(relsPart, "</Relationships>", relationship)
+}
+
+// PartNames returns the names of the parts that match the pattern (see path.Match), e.g. `word/header*.xml`.
+func (p *Package) PartNames(pattern string) []string {
+	names := []string{}
+	for _, name := range p.names {
+		if matched, _ := path.Match(pattern, name); matched {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Write writes all of the parts of the package to the writer as a zip archive.
+func (p *Package) Write(writer io.Writer) error {
+	zipWriter := zip.NewWriter(writer)
+	for _, name := range p.names {
+		partWriter, err := zipWriter.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = partWriter.Write(p.parts[name])
+		if err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
@@ -0,0 +1,155 @@
+package docx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+
+	. "github.com/opencontrol/fedramp-templater/docx"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+		`</Types>`
+	documentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings" Target="settings.xml"/>` +
+		`</Relationships>`
+	document = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Responsible Role:</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`</w:body></w:document>`
+)
+
+// part is the name and content of a part of a package.
+type part struct {
+	name    string
+	content string
+}
+
+// packageWith returns a package with the parts, in order.
+func packageWith(parts ...part) *Package {
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	for _, p := range parts {
+		partWriter, err := zipWriter.Create(p.name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = partWriter.Write([]byte(p.content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(zipWriter.Close()).To(Succeed())
+
+	pkg, err := ReadPackage(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	Expect(err).NotTo(HaveOccurred())
+	return pkg
+}
+
+// newPackage returns a package with a minimal Word document.
+func newPackage() *Package {
+	return packageWith(
+		part{"[Content_Types].xml", contentTypes},
+		part{"word/_rels/document.xml.rels", documentRels},
+		part{DocumentPart, document},
+	)
+}
+
+// partContent returns the content of the part of the package, which has to exist.
+func partContent(pkg *Package, name string) string {
+	content, exists := pkg.Part(name)
+	Expect(exists).To(BeTrue())
+	return string(content)
+}
+
+var _ = Describe("Package", func() {
+	Describe("ReadPackage", func() {
+		It("fails for a package without a document", func() {
+			buf := &bytes.Buffer{}
+			Expect(zip.NewWriter(buf).Close()).To(Succeed())
+
+			_, err := ReadPackage(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("AddContentType", func() {
+		It("registers the content type of a new part", func() {
+			pkg := newPackage()
+
+			err := pkg.AddContentType("word/comments.xml", "application/comments+xml")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partContent(pkg, "[Content_Types].xml")).To(HaveSuffix(
+				`<Override PartName="/word/comments.xml" ContentType="application/comments+xml"/></Types>`))
+		})
+
+		It("leaves the override of a part that is already registered", func() {
+			pkg := newPackage()
+
+			err := pkg.AddContentType(DocumentPart, "application/other+xml")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partContent(pkg, "[Content_Types].xml")).To(Equal(contentTypes))
+		})
+	})
+
+	Describe("AddDocumentRelationship", func() {
+		It("adds the relationship with an unused ID", func() {
+			pkg := newPackage()
+
+			err := pkg.AddDocumentRelationship("http://example.com/comments", "comments.xml")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partContent(pkg, "word/_rels/document.xml.rels")).To(HaveSuffix(
+				`<Relationship Id="rId4" Type="http://example.com/comments" Target="comments.xml"/></Relationships>`))
+		})
+
+		It("leaves a relationship that already exists", func() {
+			pkg := newPackage()
+
+			err := pkg.AddDocumentRelationship("http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles",
+				"styles.xml")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partContent(pkg, "word/_rels/document.xml.rels")).To(Equal(documentRels))
+		})
+
+		It("fails when the document doesn't have any relationships", func() {
+			pkg := packageWith(part{DocumentPart, document})
+
+			err := pkg.AddDocumentRelationship("http://example.com/comments", "comments.xml")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Write", func() {
+		It("writes all of the parts in their original order, followed by the new ones", func() {
+			pkg := newPackage()
+			pkg.SetPart("word/comments.xml", []byte("<w:comments/>"))
+			pkg.SetPart(DocumentPart, []byte("<w:document/>"))
+
+			buf := &bytes.Buffer{}
+			Expect(pkg.Write(buf)).To(Succeed())
+
+			reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			Expect(err).NotTo(HaveOccurred())
+			names := []string{}
+			for _, file := range reader.File {
+				names = append(names, file.Name)
+			}
+			Expect(names).To(Equal([]string{"[Content_Types].xml", "word/_rels/document.xml.rels", DocumentPart,
+				"word/comments.xml"}))
+			written, err := ReadPackage(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partContent(written, DocumentPart)).To(Equal("<w:document/>"))
+			Expect(strings.HasPrefix(partContent(written, "[Content_Types].xml"), "<?xml")).To(BeTrue())
+		})
+	})
+})
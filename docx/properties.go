@@ -0,0 +1,111 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CorePropertiesPart is the name of the part that contains the core properties of the Word document, e.g. the title.
+	CorePropertiesPart = "docProps/core.xml"
+	// CustomPropertiesPart is the name of the part that contains the custom properties of the Word document.
+	CustomPropertiesPart             = "docProps/custom.xml"
+	customPropertiesContentType      = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
+	customPropertiesRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
+	// customPropertyFormatID is the format ID that Word uses for all of the custom properties.
+	customPropertyFormatID = "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}"
+)
+
+// emptyCustomProperties is the content of the custom properties part for a Word document that doesn't have any yet.
+const emptyCustomProperties = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+	`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" ` +
+	`xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"></Properties>`
+
+// propertyIDRegex matches the property IDs of the custom properties, e.g. `pid="2"`.
+var propertyIDRegex = regexp.MustCompile(`pid="(\d+)"`)
+
+// escapeXML escapes the text so that it can be used as the content or an attribute value of an XML element.
+func escapeXML(text string) string {
+	buf := &bytes.Buffer{}
+	xml.EscapeText(buf, []byte(text))
+	return buf.String()
+}
+
+// replaceElement replaces the first element of the part that matches the regex, and returns whether there was one.
+func (p *Package) replaceElement(name string, regex *regexp.Regexp, element string) bool {
+	content := p.parts[name]
+	loc := regex.FindIndex(content)
+	if loc == nil {
+		return false
+	}
+	p.parts[name] = []byte(string(content[:loc[0]]) + element + string(content[loc[1]:]))
+	return true
+}
+
+// SetCoreProperty sets the core property with the provided qualified name (e.g. `dc:title` or `cp:version`) to the
+// value, adding the property if it isn't set yet. The attributes of an existing property are kept, e.g. the
+// `xsi:type="dcterms:W3CDTF"` of the dates.
+func (p *Package) SetCoreProperty(name, value string) error {
+	content, exists := p.Part(CorePropertiesPart)
+	if !exists {
+		return fmt.Errorf("%s not found", CorePropertiesPart)
+	}
+	quoted := regexp.QuoteMeta(name)
+	// the first group is the attributes of the start tag.
+	regex := regexp.MustCompile(`(?s)<` + quoted + `(\s[^>]*?)?(?:/>|>.*?</` + quoted + `>)`)
+	attributes := ""
+	if subMatches := regex.FindSubmatch(content); subMatches != nil {
+		attributes = strings.TrimSuffix(string(subMatches[1]), " ")
+	}
+	element := fmt.Sprintf(`<%s%s>%s</%s>`, name, attributes, escapeXML(value), name)
+	if p.replaceElement(CorePropertiesPart, regex, element) {
+		return nil
+	}
+	return p.insertBefore(CorePropertiesPart, "</cp:coreProperties>", element)
+}
+
+// SetCustomProperty sets the custom text property with the provided name to the value, adding the property (and the
+// custom properties part) if it isn't set yet.
+func (p *Package) SetCustomProperty(name, value string) error {
+	content, exists := p.Part(CustomPropertiesPart)
+	if !exists {
+		p.SetPart(CustomPropertiesPart, []byte(emptyCustomProperties))
+		err := p.AddContentType(CustomPropertiesPart, customPropertiesContentType)
+		if err != nil {
+			return err
+		}
+		err = p.AddPackageRelationship(customPropertiesRelationshipType, CustomPropertiesPart)
+		if err != nil {
+			return err
+		}
+	}
+
+	escapedName := escapeXML(name)
+	regex := regexp.MustCompile(`(?s)<property [^>]*name="` + regexp.QuoteMeta(escapedName) + `"[^>]*>.*?</property>`)
+	// keep the ID of an existing property, otherwise find an unused one. The IDs start at 2.
+	id := 2
+	if existing := regex.Find(content); existing != nil {
+		subMatches := propertyIDRegex.FindSubmatch(existing)
+		if len(subMatches) == 2 {
+			id, _ = strconv.Atoi(string(subMatches[1]))
+		}
+	} else {
+		for _, subMatches := range propertyIDRegex.FindAllSubmatch(content, -1) {
+			existingID, err := strconv.Atoi(string(subMatches[1]))
+			if err == nil && existingID >= id {
+				id = existingID + 1
+			}
+		}
+	}
+
+	property := fmt.Sprintf(`<property fmtid="%s" pid="%d" name="%s"><vt:lpwstr>%s</vt:lpwstr></property>`,
+		customPropertyFormatID, id, escapedName, escapeXML(value))
+	if p.replaceElement(CustomPropertiesPart, regex, property) {
+		return nil
+	}
+	return p.insertBefore(CustomPropertiesPart, "</Properties>", property)
+}
@@ -0,0 +1,45 @@
+package docx_test
+
+import (
+	. "github.com/opencontrol/fedramp-templater/docx"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const coreProperties = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+	`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+	`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" ` +
+	`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+	`<dc:title>Template</dc:title><cp:version/>` +
+	`<dcterms:modified xsi:type="dcterms:W3CDTF">2016-08-01T12:00:00Z</dcterms:modified>` +
+	`</cp:coreProperties>`
+
+var _ = Describe("Properties", func() {
+	Describe("SetCoreProperty", func() {
+		It("replaces the value of the property, keeping its attributes", func() {
+			pkg := newPackage()
+			pkg.SetPart(CorePropertiesPart, []byte(coreProperties))
+
+			Expect(pkg.SetCoreProperty("dc:title", "Amazon Web Services & Co")).To(Succeed())
+			Expect(pkg.SetCoreProperty("cp:version", "1.2")).To(Succeed())
+			Expect(pkg.SetCoreProperty("dcterms:modified", "2017-01-01T00:00:00Z")).To(Succeed())
+
+			core := partContent(pkg, CorePropertiesPart)
+			Expect(core).To(ContainSubstring(`<dc:title>Amazon Web Services &amp; Co</dc:title>`))
+			Expect(core).To(ContainSubstring(`<cp:version>1.2</cp:version>`))
+			Expect(core).To(ContainSubstring(
+				`<dcterms:modified xsi:type="dcterms:W3CDTF">2017-01-01T00:00:00Z</dcterms:modified>`))
+		})
+
+		It("adds a property that isn't set yet", func() {
+			pkg := newPackage()
+			pkg.SetPart(CorePropertiesPart, []byte(coreProperties))
+
+			Expect(pkg.SetCoreProperty("dc:subject", "SSP")).To(Succeed())
+
+			Expect(partContent(pkg, CorePropertiesPart)).To(ContainSubstring(
+				`<dc:subject>SSP</dc:subject></cp:coreProperties>`))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package fixtures
 
 import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -41,3 +44,19 @@ func LoadOpenControlFixture() opencontrols.Data {
 
 	return openControlData
 }
+
+// ReadParts returns the content of each file of a written zip package, e.g. a workbook, keyed by the name of the file.
+func ReadParts(content []byte) map[string]string {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	Expect(err).NotTo(HaveOccurred())
+	parts := map[string]string{}
+	for _, file := range reader.File {
+		readCloser, err := file.Open()
+		Expect(err).NotTo(HaveOccurred())
+		partContent, err := ioutil.ReadAll(readCloser)
+		readCloser.Close()
+		Expect(err).NotTo(HaveOccurred())
+		parts[file.Name] = string(partContent)
+	}
+	return parts
+}
@@ -0,0 +1,13 @@
+package frontmatter_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestFrontmatter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Frontmatter Suite")
+}
@@ -0,0 +1,86 @@
+package frontmatter
+
+import (
+	"sort"
+
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+)
+
+// Titles of the front-matter tables of the SSP.
+const (
+	nameTitle                = "Information System Name"
+	categorizationTitle      = "Security Categorization"
+	ownerTitle               = "Information System Owner"
+	authorizingOfficialTitle = "Authorizing Official"
+	issoTitle                = "Information System Security Officer"
+	serviceModelTitle        = "Service Model"
+	deploymentModelTitle     = "Deployment Model"
+)
+
+// Section is the fields of the front-matter tables with a title, e.g. `Information System Owner`. With a single list
+// of fields, each table with the title is filled with it. Otherwise, each table is filled with the next list (e.g. one
+// per Authorizing Official), and the tables after the last one are left as they are.
+type Section struct {
+	Title  string
+	Fields [][]Field
+}
+
+func contactFields(contact opencontrols.Contact) []Field {
+	return []Field{
+		{Label: "Name", Value: contact.Name},
+		{Label: "Title", Value: contact.Title},
+		{Label: "Company / Organization", Value: contact.Organization},
+		{Label: "Address", Value: contact.Address},
+		{Label: "Phone Number", Value: contact.Phone},
+		{Label: "Email Address", Value: contact.Email},
+	}
+}
+
+// NewSections returns the Sections of the front matter with the metadata of the system.
+func NewSections(system opencontrols.System) []Section {
+	sections := []Section{
+		{Title: nameTitle, Fields: [][]Field{{
+			{Label: "Unique Identifier", Value: system.Identifier},
+			{Label: "Information System Name", Value: system.Name},
+			{Label: "Information System Abbreviation", Value: system.Abbreviation},
+		}}},
+		{Title: categorizationTitle, Fields: [][]Field{{
+			{Label: "System Sensitivity Level", Value: system.Categorization},
+			{Label: "Security Categorization", Value: system.Categorization},
+		}}},
+		{Title: ownerTitle, Fields: [][]Field{contactFields(system.Owner)}},
+		{Title: issoTitle, Fields: [][]Field{contactFields(system.ISSO)}},
+		{Title: serviceModelTitle, Fields: [][]Field{{{Label: "Service Model", Value: system.ServiceModel}}}},
+		{Title: deploymentModelTitle, Fields: [][]Field{{{Label: "Deployment Model", Value: system.DeploymentModel}}}},
+	}
+
+	officials := Section{Title: authorizingOfficialTitle}
+	for _, official := range system.AuthorizingOfficials {
+		officials.Fields = append(officials.Fields, contactFields(official))
+	}
+	if len(officials.Fields) > 0 {
+		sections = append(sections, officials)
+	}
+
+	// sort the other contacts so that the tables are always filled in the same order.
+	titles := []string{}
+	for title := range system.Contacts {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	for _, title := range titles {
+		sections = append(sections, Section{Title: title, Fields: [][]Field{contactFields(system.Contacts[title])}})
+	}
+	return sections
+}
+
+// FieldsFor returns the fields for the table with the index (among the tables with the title of the section).
+func (s Section) FieldsFor(index int) []Field {
+	if len(s.Fields) == 1 {
+		return s.Fields[0]
+	}
+	if index < len(s.Fields) {
+		return s.Fields[index]
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package frontmatter
+
+import (
+	"strings"
+
+	"github.com/jbowtie/gokogiri/xml"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
+	"github.com/opencontrol/fedramp-templater/xml/helper"
+)
+
+// Field is the value for a labeled cell of a front-matter table, e.g. the `Name` of the Information System Owner.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Table represents the node in the Word docx XML tree that corresponds to a front-matter table of the SSP, which has a
+// label for each value.
+type Table struct {
+	Root xml.Node
+}
+
+// NewTable creates a Table instance.
+func NewTable(root xml.Node) Table {
+	return Table{Root: root}
+}
+
+// normalizeLabel ignores the case, whitespace and trailing colon of the label, so that e.g. `Company/Organization:`
+// matches `Company / Organization`.
+func normalizeLabel(text string) string {
+	label := strings.ToLower(strings.Join(strings.Fields(text), ""))
+	return strings.TrimSuffix(label, ":")
+}
+
+func (t *Table) rows() ([][]xml.Node, error) {
+	rowNodes, err := helper.SearchSubtree(t.Root, `./w:tr`)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]xml.Node{}
+	for _, rowNode := range rowNodes {
+		cells, err := helper.SearchSubtree(rowNode, `./w:tc`)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// Fill inserts the values of the fields into the table, and returns the number of cells that were filled. The value
+// of a label goes in the cell to its right when the row is a label and a value, e.g. `Name | <Enter Name>`. Otherwise,
+// the label is treated as a column header and the value goes in the cell below it. Fields without a value are skipped.
+func (t *Table) Fill(fields []Field) (int, error) {
+	values := map[string]string{}
+	for _, field := range fields {
+		if field.Value != "" {
+			values[normalizeLabel(field.Label)] = field.Value
+		}
+	}
+	isLabel := func(cell xml.Node) bool {
+		_, found := values[normalizeLabel(cell.Content())]
+		return found
+	}
+
+	rows, err := t.rows()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i, cells := range rows {
+		for j, cell := range cells {
+			if !isLabel(cell) {
+				continue
+			}
+			var valueCell xml.Node
+			if len(cells) == 2 && j == 0 && !isLabel(cells[1]) {
+				valueCell = cells[1]
+			} else if i+1 < len(rows) && j < len(rows[i+1]) {
+				valueCell = rows[i+1][j]
+			} else {
+				continue
+			}
+			err = docxHelper.SetCellText(valueCell, values[normalizeLabel(cell.Content())])
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package frontmatter_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	. "github.com/opencontrol/fedramp-templater/frontmatter"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func loadTables() []xml.Node {
+	content, err := ioutil.ReadFile(fixtures.FixturePath("front_matter.xml"))
+	Expect(err).NotTo(HaveOccurred())
+	doc, err := helper.ParseXML(content)
+	Expect(err).NotTo(HaveOccurred())
+	tables, err := doc.Search("//w:tbl")
+	Expect(err).NotTo(HaveOccurred())
+	return tables
+}
+
+// rowTexts returns the text of each row of the table, with the cells separated by `|`.
+func rowTexts(table Table) []string {
+	rows, err := table.Root.Search("./w:tr")
+	Expect(err).NotTo(HaveOccurred())
+	texts := []string{}
+	for _, row := range rows {
+		cells, err := row.Search("./w:tc")
+		Expect(err).NotTo(HaveOccurred())
+		cellTexts := []string{}
+		for _, cell := range cells {
+			cellTexts = append(cellTexts, strings.TrimSpace(cell.Content()))
+		}
+		texts = append(texts, strings.Join(cellTexts, "|"))
+	}
+	return texts
+}
+
+var _ = Describe("Table", func() {
+	Describe("Fill", func() {
+		It("fills in the values below the column headers", func() {
+			table := NewTable(loadTables()[0])
+
+			count, err := table.Fill([]Field{
+				{Label: "Unique Identifier", Value: "F1234567890"},
+				{Label: "Information System Name", Value: "My System"},
+				{Label: "Information System Abbreviation", Value: "MS"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+			content := table.Root.String()
+			Expect(content).To(ContainSubstring(`<w:rPr><w:b/></w:rPr><w:t>F1234567890</w:t>`))
+			Expect(content).To(ContainSubstring(`<w:tcW w:w="2000" w:type="dxa"/>`))
+			Expect(table.Root.Content()).To(ContainSubstring("My System"))
+			Expect(table.Root.Content()).NotTo(ContainSubstring("Enter"))
+			Expect(table.Root.Content()).To(ContainSubstring("MS"))
+		})
+
+		It("fills in the values next to the labels", func() {
+			table := NewTable(loadTables()[1])
+			owner := opencontrols.Contact{Name: "Jane Doe", Organization: "Example Corp", Email: "jane@example.com"}
+
+			count, err := table.Fill(NewSections(opencontrols.System{Owner: owner})[2].Fields[0])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+			Expect(rowTexts(table)).To(Equal([]string{
+				"Name|Jane Doe",
+				"Title|<Enter Title>",
+				"Company/Organization|Example Corp",
+				"Email Address:|jane@example.com",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Section", func() {
+	Describe("FieldsFor", func() {
+		It("returns the fields of each Authorizing Official", func() {
+			system := opencontrols.System{AuthorizingOfficials: []opencontrols.Contact{{Name: "First"}, {Name: "Second"}}}
+			sections := NewSections(system)
+			officials := sections[len(sections)-1]
+
+			Expect(officials.Title).To(Equal("Authorizing Official"))
+			Expect(officials.FieldsFor(1)[0]).To(Equal(Field{Label: "Name", Value: "Second"}))
+			Expect(officials.FieldsFor(2)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package inventory
+
+import (
+	"io"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/xlsx"
+)
+
+// sheetName is the name of the sheet of the FedRAMP Integrated Inventory Workbook with the assets.
+const sheetName = "Inventory"
+
+// header is the header row of the inventory, with the column names of the FedRAMP Integrated Inventory Workbook.
+var header = []string{
+	"Unique Asset Identifier",
+	"IPv4 or IPv6 Address",
+	"Virtual",
+	"Public",
+	"DNS Name or URL",
+	"OS Name and Version",
+	"Location",
+	"Asset Type",
+	"Hardware Make/Model",
+	"Software/Database Vendor",
+	"Software/Database Name & Version",
+	"Function",
+	"System Administrator/Owner",
+	"Component",
+	"Comments",
+}
+
+// yesNo converts the flag to the `Yes` or `No` of the workbook, leaving it blank when it isn't known.
+func yesNo(flag *bool) string {
+	if flag == nil {
+		return ""
+	}
+	if *flag {
+		return "Yes"
+	}
+	return "No"
+}
+
+// row returns the cells of the item, in the same order as the header.
+func row(item opencontrols.InventoryItem) []interface{} {
+	return []interface{}{
+		item.AssetID,
+		strings.Join(item.IPAddresses, ", "),
+		yesNo(item.Virtual),
+		yesNo(item.Public),
+		item.DNSName,
+		item.OS,
+		item.Location,
+		item.AssetType,
+		item.Hardware,
+		item.SoftwareVendor,
+		item.Software,
+		item.Function,
+		item.Owner,
+		item.Component,
+		item.Comments,
+	}
+}
+
+// WriteXLSXTo writes the items as an Integrated Inventory Workbook to the writer, one row per item.
+func WriteXLSXTo(writer io.Writer, items []opencontrols.InventoryItem) error {
+	workbook := xlsx.NewWorkbook()
+	sheet := workbook.AddSheet(sheetName)
+	sheet.AddHeader(header...)
+	for _, item := range items {
+		sheet.AddRow(row(item)...)
+	}
+	return workbook.Write(writer)
+}
@@ -0,0 +1,45 @@
+package inventory_test
+
+import (
+	"bytes"
+
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	. "github.com/opencontrol/fedramp-templater/inventory"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// readSheet returns the XML of the first sheet of the written workbook.
+func readSheet(items []opencontrols.InventoryItem) string {
+	buf := &bytes.Buffer{}
+	err := WriteXLSXTo(buf, items)
+	Expect(err).NotTo(HaveOccurred())
+
+	parts := fixtures.ReadParts(buf.Bytes())
+	Expect(parts).To(HaveKey("xl/worksheets/sheet1.xml"))
+	return parts["xl/worksheets/sheet1.xml"]
+}
+
+var _ = Describe("WriteXLSXTo", func() {
+	It("writes a row for each item of the components", func() {
+		data := fixtures.LoadOpenControlFixture()
+
+		sheetXML := readSheet(data.GetInventory())
+
+		Expect(sheetXML).To(ContainSubstring(`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Unique Asset Identifier</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="A2" t="inlineStr"><is><t xml:space="preserve">web-01</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="B2" t="inlineStr"><is><t xml:space="preserve">10.0.1.10, 10.0.1.11</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="C2" t="inlineStr"><is><t xml:space="preserve">Yes</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="D2" t="inlineStr"><is><t xml:space="preserve">No</t></is></c>`))
+		Expect(sheetXML).To(ContainSubstring(`<c r="N2" t="inlineStr"><is><t xml:space="preserve">Amazon Elastic Compute Cloud</t></is></c>`))
+		Expect(sheetXML).NotTo(ContainSubstring(`<row r="3">`))
+	})
+
+	It("leaves the flags that aren't known blank", func() {
+		sheetXML := readSheet([]opencontrols.InventoryItem{{AssetID: "db-01"}})
+
+		Expect(sheetXML).To(ContainSubstring(`<c r="C2" t="inlineStr"><is><t xml:space="preserve"></t></is></c>`))
+	})
+})
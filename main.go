@@ -1,11 +1,20 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	"github.com/opencontrol/fedramp-templater/crm"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
+	inventoryWorkbook "github.com/opencontrol/fedramp-templater/inventory"
 	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/oscal"
+	"github.com/opencontrol/fedramp-templater/reporter"
 	"github.com/opencontrol/fedramp-templater/ssp"
 	"github.com/opencontrol/fedramp-templater/templater"
 )
@@ -16,30 +25,121 @@ const (
 	_ subCommand = iota // Default value. This value is used as a placeholder when creating instances of subCommands
 	diff
 	fill
+	extract
+	merge
+	inventory
+	export
+	coverage
 )
 
 func (cmd subCommand) isType(otherCmd subCommand) bool {
 	return cmd == otherCmd
 }
 
+// Types of the export command.
+const (
+	crmExport      = "crm"
+	oscalSSPExport = "oscal-ssp"
+)
+
+// Formats of the diff report.
+const (
+	textFormat   = "text"
+	jsonFormat   = "json"
+	ndjsonFormat = "ndjson"
+	csvFormat    = "csv"
+	xlsxFormat   = "xlsx"
+	htmlFormat   = "html"
+	junitFormat  = "junit"
+	sarifFormat  = "sarif"
+)
+
+// stringList is a flag that can be passed more than once, e.g. `--standard A --standard B`.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 type options struct {
 	openControlsDir string
+	basePath        string
 	inputPath       string
 	outputPath      string
-	cmd             subCommand
+	format          string
+	componentName   string
+	certification   string
+	annotate        bool
+	trackChanges    bool
+	author          string
+	date            string
+	templateVersion string
+	templateProfile string
+	systemPath      string
+	commit          string
+	exportType      string
+	componentDefs   string
+	standards       stringList
+	// certificationPath is the certification YAML that limits the controls, as opposed to the name of the
+	// certification that extract writes.
+	certificationPath string
+	missingData       string
+	placeholder       string
+	cmd               subCommand
 }
 
 func printUsage() {
 	log.Fatal(`Usage:
-	fedramp-templater fill <openControlsDir> <inputDoc> <outputDoc>
+	fedramp-templater fill [--template-version v2.1|rev5 | --template-profile <profile.yaml>] [--annotate] [--track-changes [--author <name>] [--date <RFC 3339 date>]] [--system <system.yaml>] [--commit <commit>] [--certification <certification.yaml>] [--missing-data leave|placeholder|fail [--placeholder <text>]] [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <inputDoc> <outputDoc>
+
+	or
+
+	fedramp-templater diff [--template-version v2.1|rev5 | --template-profile <profile.yaml>] [--format text|json|ndjson|csv|xlsx|html|junit|sarif] [--annotate <outputDoc>] [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <inputDoc>
+
+	or
+
+	fedramp-templater extract [--template-version v2.1|rev5 | --template-profile <profile.yaml>] [--component <name>] [--certification <name>] <inputDoc> <outDir>
+
+	or
+
+	fedramp-templater merge [--template-version v2.1|rev5 | --template-profile <profile.yaml>] [--format text|json|ndjson|csv|xlsx|html|junit|sarif] [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <baseDoc> <editedDoc> <outputDoc>
+
+	or
+
+	fedramp-templater inventory <openControlsDir> <outputXlsx>
 
 	or
 
-	fedramp-templater diff <openControlsDir> <inputDoc>`)
+	fedramp-templater export crm [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <outputXlsx>
+
+	or
+
+	fedramp-templater export oscal-ssp [--system <system.yaml>] [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <outputJSON>
+
+	or
+
+	fedramp-templater coverage [--format text|json] [--certification <certification.yaml>] [--component-definitions <dir>] [--standard <key>]... <openControlsDir> <inputDoc>`)
+}
+
+func isValidFormat(format string) bool {
+	switch format {
+	case textFormat, jsonFormat, ndjsonFormat, csvFormat, xlsxFormat, htmlFormat, junitFormat, sarifFormat:
+		return true
+	}
+	return false
+}
+
+func isValidCoverageFormat(format string) bool {
+	return format == textFormat || format == jsonFormat
 }
 
 func parseArgs() (opts options) {
-	if len(os.Args) < 4 || len(os.Args) > 5 {
+	if len(os.Args) < 2 {
 		printUsage()
 	}
 	switch os.Args[1] {
@@ -47,27 +147,160 @@ func parseArgs() (opts options) {
 		opts.cmd = diff
 	case "fill":
 		opts.cmd = fill
+	case "extract":
+		opts.cmd = extract
+	case "merge":
+		opts.cmd = merge
+	case "inventory":
+		opts.cmd = inventory
+	case "export":
+		opts.cmd = export
+	case "coverage":
+		opts.cmd = coverage
 	default:
 		log.Printf("Unknown command: %s\n", os.Args[1])
 		printUsage()
 	}
-	if opts.cmd.isType(diff) && len(os.Args) == 4 {
-		// diff command only has four args
-		opts.openControlsDir = os.Args[2]
-		opts.inputPath = os.Args[3]
-	} else if opts.cmd.isType(fill) && len(os.Args) == 5 {
-		// fill command only has five args
-		opts.openControlsDir = os.Args[2]
-		opts.inputPath = os.Args[3]
-		opts.outputPath = os.Args[4]
+	argsStart := 2
+	if opts.cmd.isType(export) {
+		// the type of the export comes before the flags.
+		if len(os.Args) < 3 {
+			printUsage()
+		}
+		switch os.Args[2] {
+		case crmExport, oscalSSPExport:
+			opts.exportType = os.Args[2]
+		default:
+			log.Printf("Unknown export: %s\n", os.Args[2])
+			printUsage()
+		}
+		argsStart = 3
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	flags.Usage = printUsage
+	// inventory and export don't read an SSP.
+	if !opts.cmd.isType(inventory) && !opts.cmd.isType(export) {
+		flags.StringVar(&opts.templateVersion, "template-version", "", "version of the SSP template (default: detected from the document)")
+		flags.StringVar(&opts.templateProfile, "template-profile", "", "YAML file with the profile of a custom SSP template")
+	}
+	if opts.cmd.isType(diff) || opts.cmd.isType(merge) {
+		flags.StringVar(&opts.format, "format", textFormat, "output format of the diff report")
+	}
+	if opts.cmd.isType(diff) {
+		flags.StringVar(&opts.outputPath, "annotate", "", "write a copy of the SSP with a comment on each discrepancy")
+	} else if opts.cmd.isType(fill) {
+		flags.BoolVar(&opts.annotate, "annotate", false, "add a comment on each cell that differed from the YAML")
+		flags.BoolVar(&opts.trackChanges, "track-changes", false, "write the changes as tracked changes")
+		flags.StringVar(&opts.author, "author", "fedramp-templater", "author of the tracked changes")
+		flags.StringVar(&opts.date, "date", "", "date of the tracked changes (default: now)")
+		flags.StringVar(&opts.systemPath, "system", "", "YAML file with the metadata of the system for the document properties, headers and footers")
+		flags.StringVar(&opts.commit, "commit", "", "commit of the OpenControls, for the opencontrol-commit document property")
+		flags.StringVar(&opts.missingData, "missing-data", string(opencontrols.LeaveMissing), "what to fill in the fields without any information: leave them as they are, a placeholder, or fail")
+		flags.StringVar(&opts.placeholder, "placeholder", opencontrols.DefaultPlaceholder, "placeholder for the fields without any information, with --missing-data placeholder")
+	} else if opts.cmd.isType(extract) {
+		flags.StringVar(&opts.componentName, "component", "", "name of the component (default: the name of the input document)")
+		flags.StringVar(&opts.certification, "certification", "FedRAMP", "name of the certification")
+	}
+	if opts.exportType == oscalSSPExport {
+		flags.StringVar(&opts.systemPath, "system", "", "YAML file with the metadata of the system for the system characteristics")
+	}
+	if opts.cmd.isType(coverage) {
+		flags.StringVar(&opts.format, "format", textFormat, "output format of the coverage report")
+	}
+	if opts.cmd.isType(fill) || opts.cmd.isType(coverage) {
+		flags.StringVar(&opts.certificationPath, "certification", "", "YAML file of the certification, to only fill its controls")
+	}
+	// the OSCAL component definitions and standards are for the justifications, so they are only for the commands
+	// that read them.
+	if !opts.cmd.isType(extract) && !opts.cmd.isType(inventory) {
+		flags.StringVar(&opts.componentDefs, "component-definitions", "", "directory with OSCAL component definitions (JSON or YAML) to fill from, in addition to the OpenControls")
+		flags.Var(&opts.standards, "standard", "standard key of the controls, e.g. NIST-800-53-rev4 (repeatable, in priority order; default: NIST-800-53)")
+	}
+	flags.Parse(os.Args[argsStart:])
+	args := flags.Args()
+	if opts.templateVersion != "" && opts.templateProfile != "" {
+		log.Println("Only one of --template-version and --template-profile can be used")
+		printUsage()
+	}
+
+	if opts.cmd.isType(diff) && len(args) == 2 && isValidFormat(opts.format) {
+		// diff command only has two positional args
+		opts.openControlsDir = args[0]
+		opts.inputPath = args[1]
+	} else if opts.cmd.isType(fill) && len(args) == 3 {
+		// fill command only has three positional args
+		opts.openControlsDir = args[0]
+		opts.inputPath = args[1]
+		opts.outputPath = args[2]
+	} else if opts.cmd.isType(merge) && len(args) == 4 && isValidFormat(opts.format) {
+		// merge command applies the YAML changes since the base doc to the edited doc
+		opts.openControlsDir = args[0]
+		opts.basePath = args[1]
+		opts.inputPath = args[2]
+		opts.outputPath = args[3]
+	} else if opts.cmd.isType(extract) && len(args) == 2 {
+		// extract command doesn't read any OpenControls, but writes them to the output directory
+		opts.inputPath = args[0]
+		opts.outputPath = args[1]
+	} else if opts.cmd.isType(coverage) && len(args) == 2 && isValidCoverageFormat(opts.format) {
+		// coverage command reports how much of the SSP the OpenControls fill
+		opts.openControlsDir = args[0]
+		opts.inputPath = args[1]
+	} else if (opts.cmd.isType(inventory) || opts.cmd.isType(export)) && len(args) == 2 {
+		// inventory and export commands only read the OpenControls
+		opts.openControlsDir = args[0]
+		opts.outputPath = args[1]
 	} else {
 		printUsage()
 	}
 	return
 }
 
-func loadOpenControls(path string) opencontrols.Data {
-	path, err := filepath.Abs(path)
+// loadSSP loads the SSP, using the profile of the template version or profile file from the options instead of the
+// detected one.
+func loadSSP(path string, opts options) *ssp.Document {
+	doc, err := ssp.Load(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if opts.templateVersion != "" || opts.templateProfile != "" {
+		var p profile.Profile
+		if opts.templateProfile != "" {
+			p, err = profile.LoadFrom(opts.templateProfile)
+		} else {
+			p, err = profile.Get(opts.templateVersion)
+		}
+		if err != nil {
+			doc.Close()
+			log.Fatalln(err)
+		}
+		doc.SetProfile(p)
+	} else if !doc.ProfileDetected() {
+		// the default profile is only a guess for a document with controls, which the other version would mangle
+		tables, err := doc.SummaryTables()
+		if err != nil || len(tables) > 0 {
+			doc.Close()
+			log.Fatalf("Unable to detect the version of the template of `%s`, use --template-version or "+
+				"--template-profile", path)
+		}
+	}
+	log.Printf("Using the %s template profile for `%s`", doc.Profile().Version, path)
+	return doc
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// loadOpenControls loads the OpenControls, along with the OSCAL component definitions from the options.
+func loadOpenControls(opts options) opencontrols.Data {
+	path, err := filepath.Abs(opts.openControlsDir)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -76,58 +309,331 @@ func loadOpenControls(path string) opencontrols.Data {
 	if len(errors) > 0 {
 		log.Fatal(errors)
 	}
+	if opts.componentDefs != "" {
+		oscalSource, errors := opencontrols.LoadOSCALFrom(opts.componentDefs)
+		if len(errors) > 0 {
+			log.Fatal(errors)
+		}
+		openControlData.AddSource(oscalSource)
+	}
+
+	found := openControlData.GetStandards()
+	log.Printf("Found the standards %s in the OpenControls", strings.Join(found, ", "))
+	for _, standard := range opts.standards {
+		if !containsString(found, standard) {
+			log.Printf("None of the components satisfy the %s standard", standard)
+		}
+	}
+	openControlData.SetStandards(opts.standards)
+
+	if opts.certificationPath != "" {
+		certification, err := opencontrols.LoadCertification(opts.certificationPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		openControlData.SetCertification(certification)
+	}
 	return openControlData
 }
 
-func diffCmd(openControlData opencontrols.Data, doc *ssp.Document) {
+func writeDiffReport(doc *ssp.Document, reporters []reporter.Reporter, opts options) error {
+	switch opts.format {
+	case jsonFormat:
+		return reporter.WriteJSONTo(os.Stdout, reporters)
+	case ndjsonFormat:
+		return reporter.WriteNDJSONTo(os.Stdout, reporters)
+	case csvFormat:
+		return reporter.WriteCSVTo(os.Stdout, reporters)
+	case xlsxFormat:
+		return reporter.WriteXLSXTo(os.Stdout, reporters)
+	case htmlFormat:
+		return reporter.WriteHTMLTo(os.Stdout, reporters)
+	case junitFormat:
+		controls, err := templater.SummaryControls(doc)
+		if err != nil {
+			return err
+		}
+		return reporter.WriteJUnitTo(os.Stdout, controls, reporters)
+	case sarifFormat:
+		return reporter.WriteSARIFTo(os.Stdout, reporters, opts.inputPath)
+	default:
+		return reporter.WriteTextTo(os.Stdout, reporters)
+	}
+}
+
+func diffCmd(openControlData opencontrols.Data, doc *ssp.Document, opts options) {
 	reporters, err := templater.DiffSSP(doc, openControlData)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	if opts.outputPath != "" {
+		err = templater.AnnotateSSP(doc, reporters)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		writeOutputDoc(doc, opts)
+	}
+	err = writeDiffReport(doc, reporters, opts)
+	if err != nil {
+		log.Fatalln(err)
+	}
 	if len(reporters) == 0 {
 		log.Println("No diff detected")
 		return
 	}
-	for _, reporter := range reporters {
-		reporter.WriteTextTo(os.Stdout)
-	}
 	log.Fatalf("%d diffs detected\n", len(reporters))
 }
 
+func writeOutputDoc(doc *ssp.Document, opts options) {
+	outputDir := filepath.Dir(opts.outputPath)
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = doc.CopyTo(opts.outputPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func newRevision(opts options) *docxHelper.Revision {
+	date := time.Now()
+	if opts.date != "" {
+		var err error
+		date, err = time.Parse(time.RFC3339, opts.date)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	return docxHelper.NewRevision(opts.author, date)
+}
+
+// loadSystem reads the metadata of the system from the --system file, if there is one.
+func loadSystem(opts options) opencontrols.System {
+	if opts.systemPath == "" {
+		return opencontrols.System{}
+	}
+	system, err := opencontrols.LoadSystem(opts.systemPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return system
+}
+
+func fillMetadata(doc *ssp.Document, opts options) {
+	system := loadSystem(opts)
+	if opts.commit != "" {
+		system.Commit = opts.commit
+	}
+	err := templater.FillMetadata(doc, system)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	filled, err := templater.FillFrontMatter(doc, system)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Filled %d front-matter cells\n", filled)
+}
+
 func fillCmd(openControlData opencontrols.Data, doc *ssp.Document, opts options) {
-	err := templater.TemplatizeSSP(doc, openControlData)
+	policy, err := opencontrols.ParseMissingDataPolicy(opts.missingData)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	openControlData.SetMissingDataPolicy(policy, opts.placeholder)
 
-	outputDir := filepath.Dir(opts.outputPath)
-	err = os.MkdirAll(outputDir, 0755)
+	var reporters []reporter.Reporter
+	if opts.annotate {
+		// find the discrepancies before they are overwritten.
+		reporters, err = templater.DiffSSP(doc, openControlData)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	var revision *docxHelper.Revision
+	if opts.trackChanges {
+		revision = newRevision(opts)
+	}
+	err = templater.TemplatizeSSPWithTrackedChanges(doc, openControlData, revision)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	err = doc.CopyTo(opts.outputPath)
+	added, err := templater.FillPortsProtocolsServices(doc, openControlData)
+	if err != nil {
+		log.Println(err)
+	} else if added > 0 {
+		log.Printf("Added %d rows to the Ports, Protocols and Services table\n", added)
+	}
+
+	if opts.systemPath != "" || opts.commit != "" {
+		fillMetadata(doc, opts)
+	}
+
+	if opts.annotate {
+		err = templater.AnnotateSSP(doc, reporters)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	writeOutputDoc(doc, opts)
+}
+
+func mergeCmd(openControlData opencontrols.Data, doc *ssp.Document, opts options) {
+	base := loadSSP(opts.basePath, opts)
+	defer base.Close()
+
+	conflicts, err := templater.MergeSSP(base, doc, openControlData)
 	if err != nil {
 		log.Fatalln(err)
 	}
+
+	writeOutputDoc(doc, opts)
+
+	err = writeDiffReport(doc, conflicts, opts)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if len(conflicts) == 0 {
+		log.Println("No conflicts detected")
+		return
+	}
+	log.Fatalf("%d conflicts detected\n", len(conflicts))
 }
 
-func main() {
-	opts := parseArgs()
+// componentKey converts the component name to a key that can be used as a directory name, e.g. `My System` to
+// `my_system`.
+func componentKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(name))
+}
+
+func extractCmd(doc *ssp.Document, opts options) {
+	justifications, err := templater.ExtractSSP(doc)
+	if err != nil {
+		log.Fatalln(err)
+	}
 
-	openControlData := loadOpenControls(opts.openControlsDir)
+	name := opts.componentName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(opts.inputPath), filepath.Ext(opts.inputPath))
+	}
+	component := templater.NewComponent(name, componentKey(name), justifications)
+	certification := templater.NewCertification(opts.certification, justifications)
+
+	err = opencontrols.WriteWorkspace(opts.outputPath, component, certification)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Extracted %d controls\n", len(component.Satisfies))
+}
 
-	doc, err := ssp.Load(opts.inputPath)
+// createOutputFile creates the output file (and its directory) for the commands that don't write an SSP.
+func createOutputFile(opts options) *os.File {
+	err := os.MkdirAll(filepath.Dir(opts.outputPath), 0755)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	output, err := os.Create(opts.outputPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return output
+}
+
+func inventoryCmd(openControlData opencontrols.Data, opts options) {
+	items := openControlData.GetInventory()
+	output := createOutputFile(opts)
+	defer output.Close()
+	err := inventoryWorkbook.WriteXLSXTo(output, items)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Exported %d inventory items to %s\n", len(items), opts.outputPath)
+}
+
+func exportCmd(openControlData opencontrols.Data, opts options) {
+	output := createOutputFile(opts)
+	defer output.Close()
+	var err error
+	switch opts.exportType {
+	case oscalSSPExport:
+		err = oscal.WriteSSPTo(output, openControlData, loadSystem(opts), time.Now())
+	default:
+		err = crm.WriteXLSXTo(output, openControlData)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Exported %s to %s\n", opts.exportType, opts.outputPath)
+}
+
+func coverageCmd(openControlData opencontrols.Data, doc *ssp.Document, opts options) {
+	result, err := templater.CheckCoverage(doc, openControlData)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if opts.format == jsonFormat {
+		err = result.WriteJSONTo(os.Stdout)
+	} else {
+		err = result.WriteTextTo(os.Stdout)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("%.1f%% of the fields would be filled\n", result.Overall().Percentage())
+	if !result.HasCertification {
+		return
+	}
+	if result.IsEmpty() {
+		log.Println("No certification gaps detected")
+		return
+	}
+	log.Fatalln("Certification gaps detected")
+}
+
+func main() {
+	opts := parseArgs()
+
+	// inventory and export only read the OpenControls.
+	if opts.cmd.isType(inventory) {
+		inventoryCmd(loadOpenControls(opts), opts)
+		return
+	} else if opts.cmd.isType(export) {
+		exportCmd(loadOpenControls(opts), opts)
+		return
+	}
+
+	doc := loadSSP(opts.inputPath, opts)
 	defer doc.Close()
 
+	// extract creates the OpenControls, so they aren't loaded.
+	if opts.cmd.isType(extract) {
+		extractCmd(doc, opts)
+		return
+	}
+
+	openControlData := loadOpenControls(opts)
+
 	// right now we don't want to do a fill and diff together.
 	if opts.cmd.isType(diff) {
-		diffCmd(openControlData, doc)
+		diffCmd(openControlData, doc, opts)
 
 	} else if opts.cmd.isType(fill) {
 		fillCmd(openControlData, doc, opts)
+
+	} else if opts.cmd.isType(merge) {
+		mergeCmd(openControlData, doc, opts)
+
+	} else if opts.cmd.isType(coverage) {
+		coverageCmd(openControlData, doc, opts)
 	}
 }
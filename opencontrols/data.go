@@ -1,6 +1,10 @@
 package opencontrols
 
 import (
+	"regexp"
+	"sort"
+	"strconv"
+
 	"github.com/opencontrol/compliance-masonry/commands/docs/docx"
 	"github.com/opencontrol/compliance-masonry/models"
 	"github.com/opencontrol/fedramp-templater/common/origin"
@@ -8,11 +12,25 @@ import (
 	"gopkg.in/fatih/set.v0"
 )
 
+// standardKey is the standard that the controls are looked up in when no standards are set.
 const standardKey = "NIST-800-53"
 
 // Data contains the OpenControl justification information.
 type Data struct {
-	ocd docx.OpenControlDocx
+	source     Source
+	extensions []componentExtensions
+	// standards are the standards that the controls are looked up in, in priority order.
+	standards []string
+	// certification limits the controls that are filled, if it is set.
+	certification *Certification
+	// missingPolicy and placeholder are for the fields without any information.
+	missingPolicy MissingDataPolicy
+	placeholder   string
+}
+
+// NewData creates a new Data struct with the justifications of the source.
+func NewData(source Source) Data {
+	return Data{source: source}
 }
 
 // LoadFrom creates a new Data struct from the provided path to an `opencontrols/` directory.
@@ -23,33 +41,179 @@ func LoadFrom(dirPath string) (data Data, errors []error) {
 	}
 
 	ocd := docx.OpenControlDocx{openControlData}
-	data = Data{ocd}
+	extensions, errors := loadComponentExtensions(dirPath)
+	if len(errors) > 0 {
+		return
+	}
+	data = Data{source: masonrySource{ocd, extensions}, extensions: extensions}
 	return
 }
 
+// AddSource adds the components of the source to the ones of the data.
+func (d *Data) AddSource(source Source) {
+	d.source = Combine(d.source, source)
+}
+
+// SetStandards sets the standards (e.g. `NIST-800-53-rev4`) that the controls are looked up in, in priority order. The
+// justifications of a control come from the first of the standards that any of the components satisfy it in.
+func (d *Data) SetStandards(standards []string) {
+	d.standards = standards
+}
+
+// getStandards returns the standards that the controls are looked up in.
+func (d *Data) getStandards() []string {
+	if len(d.standards) == 0 {
+		return []string{standardKey}
+	}
+	return d.standards
+}
+
+// standardFor returns the standard to look up the control in, which is the first standard with a justification for
+// it, or the first standard if none of them have one.
+func (d *Data) standardFor(control string) string {
+	standards := d.getStandards()
+	for _, standard := range standards {
+		if len(d.source.ComponentSatisfies(standard, control)) > 0 {
+			return standard
+		}
+	}
+	return standards[0]
+}
+
+// SetCertification limits the controls that are filled to the ones of the certification, in any of the standards.
+func (d *Data) SetCertification(certification Certification) {
+	d.certification = &certification
+}
+
+// InCertification returns whether the control is in the certification, in any of the standards. All of the controls
+// are in it when no certification is set.
+func (d *Data) InCertification(control string) bool {
+	if d.certification == nil {
+		return true
+	}
+	for _, standard := range d.getStandards() {
+		if d.certification.HasControl(standard, control) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCertificationControls returns the controls of the certification in any of the standards, sorted by family and
+// number. It returns nil when no certification is set.
+func (d *Data) GetCertificationControls() []string {
+	if d.certification == nil {
+		return nil
+	}
+	found := map[string]bool{}
+	controls := []string{}
+	for _, standard := range d.getStandards() {
+		for control := range d.certification.Standards[standard] {
+			if !found[control] {
+				found[control] = true
+				controls = append(controls, control)
+			}
+		}
+	}
+	sortControls(controls)
+	return controls
+}
+
+// GetStandards returns the standards that are satisfied by any of the components, sorted, e.g. to check which ones
+// can be passed to SetStandards.
+func (d *Data) GetStandards() []string {
+	standards := d.source.Standards()
+	sort.Strings(standards)
+	return standards
+}
+
 // GetResponsibleRoles returns the responsible role information for each component matching the specified control.
 func (d *Data) GetResponsibleRoles(control string) string {
-	return d.ocd.FormatResponsibleRoles(standardKey, control)
+	return d.source.ResponsibleRoles(d.standardFor(control), control)
 }
 
 // GetParameter returns the responsible role information for each component matching the specified control.
 func (d *Data) GetParameter(control string, sectionKey string) string {
-	return d.ocd.FormatParameter(standardKey, control, sectionKey)
+	return d.source.Parameter(d.standardFor(control), control, sectionKey)
 }
 
 // GetNarrative returns the justification text for the specified control. Pass an empty string for `sectionKey` if you are looking for the overall narrative.
 func (d *Data) GetNarrative(control string, sectionKey string) string {
-	return d.ocd.FormatNarrative(standardKey, control, sectionKey)
+	return d.source.Narrative(d.standardFor(control), control, sectionKey)
+}
+
+// controlRegex matches the controls (e.g. `AC-2 (1)`), for sorting.
+var controlRegex = regexp.MustCompile(`^([A-Z]+)-(\d+)(?: \((\d+)\))?`)
+
+// controlSortKey returns the family, number and enhancement number of the control, so that e.g. `AC-10` comes after
+// `AC-2 (1)`.
+func controlSortKey(control string) (string, int, int) {
+	subMatches := controlRegex.FindStringSubmatch(control)
+	if subMatches == nil {
+		return control, 0, 0
+	}
+	number, _ := strconv.Atoi(subMatches[2])
+	enhancement, _ := strconv.Atoi(subMatches[3])
+	return subMatches[1], number, enhancement
+}
+
+// sortControls sorts the controls by family and number.
+func sortControls(controls []string) {
+	sort.Slice(controls, func(i, j int) bool {
+		familyI, numberI, enhancementI := controlSortKey(controls[i])
+		familyJ, numberJ, enhancementJ := controlSortKey(controls[j])
+		if familyI != familyJ {
+			return familyI < familyJ
+		}
+		if numberI != numberJ {
+			return numberI < numberJ
+		}
+		return enhancementI < enhancementJ
+	})
+}
+
+// GetControls returns the controls that are satisfied by any of the components in any of the standards, sorted by
+// family and number.
+func (d *Data) GetControls() []string {
+	found := map[string]bool{}
+	controls := []string{}
+	for _, standard := range d.getStandards() {
+		for _, control := range d.source.Controls(standard) {
+			if !found[control] {
+				found[control] = true
+				controls = append(controls, control)
+			}
+		}
+	}
+	sortControls(controls)
+	return controls
+}
+
+// GetNarrativeKeys returns the keys of the narrative sections (e.g. `a`) of each component for the specified control,
+// sorted. The key is an empty string for an overall narrative.
+func (d *Data) GetNarrativeKeys(control string) []string {
+	found := map[string]bool{}
+	keys := []string{}
+	for _, justification := range d.GetComponentSatisfies(control) {
+		for _, narrative := range justification.Narrative {
+			if !found[narrative.Key] {
+				found[narrative.Key] = true
+				keys = append(keys, narrative.Key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetComponentSatisfies returns the justification of each component matching the specified control.
+func (d *Data) GetComponentSatisfies(control string) []ComponentSatisfies {
+	return d.source.ComponentSatisfies(d.standardFor(control), control)
 }
 
 // GetControlOrigins returns the control origination information for each component matching the specified control.
 func (d *Data) GetControlOrigins(control string) ControlOrigins {
-	controlOrigins := ControlOrigins{}
-	justifications := d.ocd.Justifications.Get(standardKey, control)
-	for _, justification := range justifications {
-		controlOrigins.origins = append(controlOrigins.origins, justification.SatisfiesData.GetControlOrigin())
-	}
-	return controlOrigins
+	return ControlOrigins{origins: d.source.ControlOrigins(d.standardFor(control), control)}
 }
 
 // ControlOrigins is a wrapper for the extracted data from the YAML for a particular control.
@@ -84,12 +248,7 @@ func (origins ControlOrigins) GetCheckedOrigins() *set.Set {
 
 // GetImplementationStatuses returns the implementation status information for each component matching the specified control.
 func (d *Data) GetImplementationStatuses(control string) ImplementationStatuses {
-	implementationStatuss := ImplementationStatuses{}
-	justifications := d.ocd.Justifications.Get(standardKey, control)
-	for _, justification := range justifications {
-		implementationStatuss.statuses = append(implementationStatuss.statuses, justification.SatisfiesData.GetImplementationStatus())
-	}
-	return implementationStatuss
+	return ImplementationStatuses{statuses: d.source.ImplementationStatuses(d.standardFor(control), control)}
 }
 
 // ImplementationStatuses is a wrapper for the extracted data from the YAML for a particular control.
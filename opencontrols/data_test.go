@@ -1,12 +1,40 @@
 package opencontrols_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+// loadStandardsWorkspace loads a workspace with a component that satisfies AC-2 in two standards, and AU-2 in only
+// one of them.
+func loadStandardsWorkspace(dir string) opencontrols.Data {
+	component := opencontrols.NewComponent("My System", "my_system")
+	satisfies := opencontrols.NewSatisfies("AC-2")
+	satisfies.Narrative = []opencontrols.Section{{Text: "Justification for NIST-800-53"}}
+	component.Satisfies = append(component.Satisfies, satisfies)
+	for _, control := range []string{"AC-2", "AU-2"} {
+		satisfies = opencontrols.NewSatisfies(control)
+		satisfies.StandardKey = "NIST-800-53-rev4"
+		satisfies.Narrative = []opencontrols.Section{{Text: "Justification for NIST-800-53-rev4"}}
+		component.Satisfies = append(component.Satisfies, satisfies)
+	}
+	certification := opencontrols.NewCertification("FedRAMP", []string{"AC-2"})
+	Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+	// compliance-masonry needs a standards directory, even if it's empty.
+	Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+	data, errors := opencontrols.LoadFrom(dir)
+	Expect(errors).To(BeEmpty())
+	return data
+}
+
 var _ = Describe("Data", func() {
 	Describe("GetNarrative", func() {
 		It("returns the relevant singular narrative", func() {
@@ -21,4 +49,177 @@ var _ = Describe("Data", func() {
 			Expect(result).To(Equal("Amazon Elastic Compute Cloud\nJustification in narrative form A for AC-2\n"))
 		})
 	})
+
+	Describe("GetPortsProtocolsServices", func() {
+		It("returns the entries of the components", func() {
+			data := fixtures.LoadOpenControlFixture()
+			result := data.GetPortsProtocolsServices()
+			Expect(result).To(Equal([]opencontrols.PortProtocolService{
+				{Ports: "443", Protocols: "TCP", Services: "HTTPS", Purpose: "Web console and API", UsedBy: "Amazon Elastic Compute Cloud"},
+				{Ports: "22", Protocols: "TCP", Services: "SSH", Purpose: "Administration", UsedBy: "Administrators"},
+			}))
+		})
+	})
+
+	Describe("GetInventory", func() {
+		It("returns the items of the components", func() {
+			data := fixtures.LoadOpenControlFixture()
+			result := data.GetInventory()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].AssetID).To(Equal("web-01"))
+			Expect(result[0].IPAddresses).To(Equal([]string{"10.0.1.10", "10.0.1.11"}))
+			Expect(*result[0].Virtual).To(BeTrue())
+			Expect(result[0].Hardware).To(BeEmpty())
+			Expect(result[0].Component).To(Equal("Amazon Elastic Compute Cloud"))
+		})
+
+		It("merges the items of the same asset of several components", func() {
+			dir, err := ioutil.TempDir("", "inventory")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			inventories := map[string]string{
+				"Database": "- asset_id: db-01\n  ip_addresses: [10.0.2.10]\n  os: Ubuntu 16.04\n" +
+					"- asset_id: db-02\n",
+				"Web Server": "- asset_id: db-01\n  ip_addresses: [10.0.2.10, 10.0.2.11]\n  virtual: true\n" +
+					"  os: Debian 8\n- ip_addresses: [10.0.1.10]\n",
+			}
+			for _, name := range []string{"Database", "Web Server"} {
+				key := strings.ToLower(strings.Replace(name, " ", "_", -1))
+				component := opencontrols.NewComponent(name, key)
+				certification := opencontrols.NewCertification("FedRAMP", []string{"AC-2"})
+				Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+				path := filepath.Join(dir, "components", key, "component.yaml")
+				content, err := ioutil.ReadFile(path)
+				Expect(err).NotTo(HaveOccurred())
+				content = append(content, "inventory:\n"+inventories[name]...)
+				Expect(ioutil.WriteFile(path, content, 0644)).To(Succeed())
+			}
+			Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+			data, errors := opencontrols.LoadFrom(dir)
+			Expect(errors).To(BeEmpty())
+
+			result := data.GetInventory()
+
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].AssetID).To(Equal("db-01"))
+			Expect(result[0].Component).To(Equal("Database, Web Server"))
+			Expect(result[0].IPAddresses).To(Equal([]string{"10.0.2.10", "10.0.2.11"}))
+			Expect(*result[0].Virtual).To(BeTrue())
+			Expect(result[0].OS).To(Equal("Ubuntu 16.04"))
+			Expect(result[1].AssetID).To(Equal("db-02"))
+			Expect(result[1].Component).To(Equal("Database"))
+			Expect(result[2].AssetID).To(BeEmpty())
+			Expect(result[2].Component).To(Equal("Web Server"))
+		})
+	})
+
+	Describe("GetResponsibleRoles", func() {
+		It("uses the responsible role of the control over the one of the component", func() {
+			dir, err := ioutil.TempDir("", "roles")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			component := opencontrols.NewComponent("My System", "my_system")
+			component.ResponsibleRole = "Staff"
+			satisfies := opencontrols.NewSatisfies("AC-2")
+			satisfies.ResponsibleRole = "Admin"
+			component.Satisfies = append(component.Satisfies, satisfies, opencontrols.NewSatisfies("AU-2"))
+			certification := opencontrols.NewCertification("FedRAMP", []string{"AC-2", "AU-2"})
+			Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+			data, errors := opencontrols.LoadFrom(dir)
+			Expect(errors).To(BeEmpty())
+
+			Expect(data.GetResponsibleRoles("AC-2")).To(Equal("My System: Admin\n"))
+			Expect(data.GetResponsibleRoles("AU-2")).To(Equal("My System: Staff\n"))
+		})
+	})
+
+	Describe("GetControls", func() {
+		It("returns the controls of the components in order", func() {
+			data := fixtures.LoadOpenControlFixture()
+			Expect(data.GetControls()).To(Equal([]string{"AC-2", "AC-2 (1)"}))
+		})
+	})
+
+	Describe("GetNarrativeKeys", func() {
+		It("returns the keys of the narrative sections", func() {
+			data := fixtures.LoadOpenControlFixture()
+			Expect(data.GetNarrativeKeys("AC-2")).To(Equal([]string{"a", "b"}))
+		})
+
+		It("returns an empty key for an overall narrative", func() {
+			data := fixtures.LoadOpenControlFixture()
+			Expect(data.GetNarrativeKeys("AC-2 (1)")).To(Equal([]string{""}))
+		})
+	})
+
+	Describe("GetComponentSatisfies", func() {
+		It("returns the justification of each component", func() {
+			data := fixtures.LoadOpenControlFixture()
+			result := data.GetComponentSatisfies("AC-2")
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].ComponentName).To(Equal("Amazon Elastic Compute Cloud"))
+			Expect(result[0].ResponsibleRole).To(Equal("AWS Staff"))
+			Expect(result[0].Narrative).To(HaveLen(2))
+			Expect(result[0].Narrative[1]).To(Equal(opencontrols.Section{Key: "b", Text: "Justification in narrative form B for AC-2"}))
+			Expect(result[0].AllControlOrigins()).To(Equal([]string{"shared"}))
+			Expect(result[0].AllImplementationStatuses()).To(Equal([]string{"partial"}))
+		})
+	})
+
+	Describe("SetCertification", func() {
+		It("limits the controls to the ones of the certification", func() {
+			data := fixtures.LoadOpenControlFixture()
+			Expect(data.InCertification("AC-2 (1)")).To(BeTrue())
+			Expect(data.GetCertificationControls()).To(BeNil())
+
+			certification, err := opencontrols.LoadCertification(
+				filepath.Join(fixtures.OpenControlFixturePath(), "certifications", "LATO.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			data.SetCertification(certification)
+
+			Expect(data.InCertification("AC-2")).To(BeTrue())
+			Expect(data.InCertification("AC-2 (1)")).To(BeFalse())
+			Expect(data.GetCertificationControls()).To(Equal([]string{"AC-2", "AC-6", "CM-2"}))
+		})
+	})
+
+	Describe("SetStandards", func() {
+		var (
+			dir  string
+			data opencontrols.Data
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "standards")
+			Expect(err).NotTo(HaveOccurred())
+			data = loadStandardsWorkspace(dir)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("looks up the controls in NIST-800-53 by default", func() {
+			Expect(data.GetControls()).To(Equal([]string{"AC-2"}))
+			Expect(data.GetNarrative("AC-2", "")).To(Equal("My System\nJustification for NIST-800-53\n"))
+		})
+
+		It("looks up each control in the first standard that has it", func() {
+			data.SetStandards([]string{"NIST-800-53", "NIST-800-53-rev4"})
+			Expect(data.GetControls()).To(Equal([]string{"AC-2", "AU-2"}))
+			Expect(data.GetNarrative("AC-2", "")).To(Equal("My System\nJustification for NIST-800-53\n"))
+			Expect(data.GetNarrative("AU-2", "")).To(Equal("My System\nJustification for NIST-800-53-rev4\n"))
+		})
+
+		It("uses the priority order of the standards", func() {
+			data.SetStandards([]string{"NIST-800-53-rev4", "NIST-800-53"})
+			Expect(data.GetNarrative("AC-2", "")).To(Equal("My System\nJustification for NIST-800-53-rev4\n"))
+		})
+
+		It("returns the standards of the components", func() {
+			Expect(data.GetStandards()).To(Equal([]string{"NIST-800-53", "NIST-800-53-rev4"}))
+		})
+	})
 })
@@ -0,0 +1,202 @@
+package opencontrols
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PortProtocolService is an entry of the Ports, Protocols and Services table of the SSP, e.g. HTTPS on port 443.
+type PortProtocolService struct {
+	Ports     string `yaml:"ports"`
+	Protocols string `yaml:"protocols"`
+	Services  string `yaml:"services"`
+	Purpose   string `yaml:"purpose"`
+	// UsedBy defaults to the name of the component.
+	UsedBy string `yaml:"used_by"`
+}
+
+// InventoryItem is an asset of the system for the FedRAMP Integrated Inventory Workbook, e.g. a virtual machine.
+type InventoryItem struct {
+	// AssetID is the unique asset identifier.
+	AssetID     string   `yaml:"asset_id"`
+	IPAddresses []string `yaml:"ip_addresses"`
+	// Virtual and Public are nil when they aren't known.
+	Virtual *bool  `yaml:"virtual"`
+	Public  *bool  `yaml:"public"`
+	DNSName string `yaml:"dns_name"`
+	// OS is the name and version of the operating system.
+	OS        string `yaml:"os"`
+	Location  string `yaml:"location"`
+	AssetType string `yaml:"asset_type"`
+	// Hardware is the make and model of the hardware.
+	Hardware       string `yaml:"hardware"`
+	SoftwareVendor string `yaml:"software_vendor"`
+	// Software is the name and version of the software or database.
+	Software string `yaml:"software"`
+	Function string `yaml:"function"`
+	Owner    string `yaml:"owner"`
+	Comments string `yaml:"comments"`
+	// Component is the name of the component that hosts the asset, or the names of all of them, separated by commas.
+	Component string `yaml:"-"`
+}
+
+// satisfiesExtensions contains the fields of a control of a `component.yaml` that aren't part of the OpenControl
+// schema.
+type satisfiesExtensions struct {
+	ControlKey      string `yaml:"control_key"`
+	StandardKey     string `yaml:"standard_key"`
+	ResponsibleRole string `yaml:"responsible_role"`
+}
+
+// componentExtensions contains the fields of a `component.yaml` that aren't part of the OpenControl schema, and
+// therefore aren't read by compliance-masonry.
+type componentExtensions struct {
+	Name                   string                `yaml:"name"`
+	Key                    string                `yaml:"key"`
+	PortsProtocolsServices []PortProtocolService `yaml:"ports_protocols_services"`
+	Inventory              []InventoryItem       `yaml:"inventory"`
+	Satisfies              []satisfiesExtensions `yaml:"satisfies"`
+}
+
+// controlResponsibleRole returns the responsible role that the component sets for the control, if any.
+func (c componentExtensions) controlResponsibleRole(standard, control string) string {
+	for _, satisfies := range c.Satisfies {
+		if satisfies.StandardKey == standard && satisfies.ControlKey == control {
+			return satisfies.ResponsibleRole
+		}
+	}
+	return ""
+}
+
+// loadComponentExtensions reads the extensions of each component in the `components/` directory of the provided
+// `opencontrols/` directory.
+func loadComponentExtensions(dirPath string) ([]componentExtensions, []error) {
+	componentsDir := filepath.Join(dirPath, "components")
+	entries, err := ioutil.ReadDir(componentsDir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	// ReadDir sorts the entries by name, so that the components are always in the same order.
+	var extensions []componentExtensions
+	var errors []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(componentsDir, entry.Name(), "component.yaml")
+		content, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		var component componentExtensions
+		err = yaml.Unmarshal(content, &component)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("unable to parse %s: %s", path, err))
+			continue
+		}
+		if component.Name == "" {
+			component.Name = entry.Name()
+		}
+		// compliance-masonry defaults the key to the name of the directory as well.
+		if component.Key == "" {
+			component.Key = entry.Name()
+		}
+		extensions = append(extensions, component)
+	}
+	return extensions, errors
+}
+
+// GetPortsProtocolsServices returns the ports, protocols and services of all of the components.
+func (d *Data) GetPortsProtocolsServices() []PortProtocolService {
+	entries := []PortProtocolService{}
+	for _, component := range d.extensions {
+		for _, entry := range component.PortsProtocolsServices {
+			if entry.UsedBy == "" {
+				entry.UsedBy = component.Name
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// mergeInventoryItem fills in the fields of the item that aren't set with the ones of the other item of the same asset,
+// and adds the IP addresses that it doesn't have yet.
+func mergeInventoryItem(item *InventoryItem, other InventoryItem) {
+	for _, ip := range other.IPAddresses {
+		if !containsString(item.IPAddresses, ip) {
+			item.IPAddresses = append(item.IPAddresses, ip)
+		}
+	}
+	if item.Virtual == nil {
+		item.Virtual = other.Virtual
+	}
+	if item.Public == nil {
+		item.Public = other.Public
+	}
+	fields := []struct{ value, otherValue *string }{
+		{&item.DNSName, &other.DNSName},
+		{&item.OS, &other.OS},
+		{&item.Location, &other.Location},
+		{&item.AssetType, &other.AssetType},
+		{&item.Hardware, &other.Hardware},
+		{&item.SoftwareVendor, &other.SoftwareVendor},
+		{&item.Software, &other.Software},
+		{&item.Function, &other.Function},
+		{&item.Owner, &other.Owner},
+		{&item.Comments, &other.Comments},
+	}
+	for _, field := range fields {
+		if *field.value == "" {
+			*field.value = *field.otherValue
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInventory returns the inventory of all of the components. The items with the same asset ID (e.g. a server that
+// hosts several of the components) are merged into one, with the names of the components joined in Component.
+func (d *Data) GetInventory() []InventoryItem {
+	items := []InventoryItem{}
+	// the index of the item and the names of the components of each asset ID.
+	indexes := map[string]int{}
+	components := map[string][]string{}
+	for _, component := range d.extensions {
+		for _, item := range component.Inventory {
+			item.Component = component.Name
+			if item.AssetID == "" {
+				items = append(items, item)
+				continue
+			}
+			idx, found := indexes[item.AssetID]
+			if !found {
+				indexes[item.AssetID] = len(items)
+				components[item.AssetID] = []string{component.Name}
+				items = append(items, item)
+				continue
+			}
+			mergeInventoryItem(&items[idx], item)
+			if !containsString(components[item.AssetID], component.Name) {
+				components[item.AssetID] = append(components[item.AssetID], component.Name)
+				items[idx].Component = strings.Join(components[item.AssetID], ", ")
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,66 @@
+package opencontrols
+
+import "fmt"
+
+// MissingDataPolicy is what is filled in a field of the SSP that the OpenControls don't have any information for.
+type MissingDataPolicy string
+
+// Policies for the missing data.
+const (
+	// LeaveMissing leaves the field of the SSP as it is, e.g. so that hand-written text isn't overwritten.
+	LeaveMissing MissingDataPolicy = "leave"
+	// PlaceholderForMissing fills the field with the placeholder.
+	PlaceholderForMissing MissingDataPolicy = "placeholder"
+	// FailOnMissing gives a MissingDataError for the field.
+	FailOnMissing MissingDataPolicy = "fail"
+)
+
+// DefaultPlaceholder is the placeholder for the missing data when none is set.
+const DefaultPlaceholder = "TBD"
+
+// ParseMissingDataPolicy returns the policy with the provided name, e.g. `leave`.
+func ParseMissingDataPolicy(name string) (MissingDataPolicy, error) {
+	switch policy := MissingDataPolicy(name); policy {
+	case LeaveMissing, PlaceholderForMissing, FailOnMissing:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown missing data policy: %s", name)
+}
+
+// MissingDataError is the error for a field that the OpenControls don't have any information for, with the
+// FailOnMissing policy.
+type MissingDataError struct {
+	Control string
+	// Field is the field of the control, e.g. `Narrative a`.
+	Field string
+}
+
+func (e MissingDataError) Error() string {
+	return fmt.Sprintf("no information found for the %s of control %s", e.Field, e.Control)
+}
+
+// SetMissingDataPolicy sets what is filled in the fields that the OpenControls don't have any information for. The
+// placeholder is only used with PlaceholderForMissing, and defaults to DefaultPlaceholder.
+func (d *Data) SetMissingDataPolicy(policy MissingDataPolicy, placeholder string) {
+	d.missingPolicy = policy
+	d.placeholder = placeholder
+}
+
+// ValueToFill returns the value to fill in the field of the control for the text from one of the getters (e.g.
+// GetNarrative), and whether the field should be filled at all. Text without any information (see HasInformation) is
+// handled according to the missing data policy, which leaves the field as it is by default.
+func (d *Data) ValueToFill(control, field, text string) (value string, fill bool, err error) {
+	if HasInformation(text) {
+		return text, true, nil
+	}
+	switch d.missingPolicy {
+	case PlaceholderForMissing:
+		if d.placeholder == "" {
+			return DefaultPlaceholder, true, nil
+		}
+		return d.placeholder, true, nil
+	case FailOnMissing:
+		return "", false, MissingDataError{Control: control, Field: field}
+	}
+	return "", false, nil
+}
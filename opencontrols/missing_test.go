@@ -0,0 +1,64 @@
+package opencontrols_test
+
+import (
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValueToFill", func() {
+	noInformation := "No information found for the combination of standard NIST-800-53 and control AC-6"
+
+	It("returns the text with information", func() {
+		data := fixtures.LoadOpenControlFixture()
+		data.SetMissingDataPolicy(opencontrols.FailOnMissing, "")
+
+		value, fill, err := data.ValueToFill("AC-2", "Responsible Role", "AWS Staff\n")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fill).To(BeTrue())
+		Expect(value).To(Equal("AWS Staff\n"))
+	})
+
+	It("doesn't fill the missing data by default", func() {
+		data := fixtures.LoadOpenControlFixture()
+
+		_, fill, err := data.ValueToFill("AC-6", "Responsible Role", noInformation)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fill).To(BeFalse())
+	})
+
+	It("fills the placeholder for the missing data", func() {
+		data := fixtures.LoadOpenControlFixture()
+		data.SetMissingDataPolicy(opencontrols.PlaceholderForMissing, "")
+
+		value, fill, err := data.ValueToFill("AC-2", "Narrative c", "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fill).To(BeTrue())
+		Expect(value).To(Equal(opencontrols.DefaultPlaceholder))
+	})
+
+	It("gives an error for the missing data", func() {
+		data := fixtures.LoadOpenControlFixture()
+		data.SetMissingDataPolicy(opencontrols.FailOnMissing, "")
+
+		_, _, err := data.ValueToFill("AC-6", "Responsible Role", noInformation)
+
+		Expect(err).To(MatchError("no information found for the Responsible Role of control AC-6"))
+	})
+})
+
+var _ = Describe("ParseMissingDataPolicy", func() {
+	It("returns the policy with the name", func() {
+		Expect(opencontrols.ParseMissingDataPolicy("placeholder")).To(Equal(opencontrols.PlaceholderForMissing))
+	})
+
+	It("gives an error for an unknown policy", func() {
+		_, err := opencontrols.ParseMissingDataPolicy("ignore")
+		Expect(err).To(HaveOccurred())
+	})
+})
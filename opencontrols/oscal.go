@@ -0,0 +1,233 @@
+package opencontrols
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"gopkg.in/yaml.v2"
+)
+
+// The structs below only contain the parts of an OSCAL component definition that are used. They have both JSON and
+// YAML tags, since OSCAL documents can be in either format.
+
+type oscalProp struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+type oscalRole struct {
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+}
+
+type oscalResponsibleRole struct {
+	RoleID string `json:"role-id" yaml:"role-id"`
+}
+
+type oscalSetParameter struct {
+	ParamID string   `json:"param-id" yaml:"param-id"`
+	Values  []string `json:"values" yaml:"values"`
+}
+
+type oscalStatement struct {
+	StatementID string `json:"statement-id" yaml:"statement-id"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type oscalImplementedRequirement struct {
+	ControlID        string                 `json:"control-id" yaml:"control-id"`
+	Description      string                 `json:"description" yaml:"description"`
+	Props            []oscalProp            `json:"props" yaml:"props"`
+	SetParameters    []oscalSetParameter    `json:"set-parameters" yaml:"set-parameters"`
+	ResponsibleRoles []oscalResponsibleRole `json:"responsible-roles" yaml:"responsible-roles"`
+	Statements       []oscalStatement       `json:"statements" yaml:"statements"`
+}
+
+type oscalControlImplementation struct {
+	ImplementedRequirements []oscalImplementedRequirement `json:"implemented-requirements" yaml:"implemented-requirements"`
+}
+
+type oscalComponent struct {
+	UUID                   string                       `json:"uuid" yaml:"uuid"`
+	Title                  string                       `json:"title" yaml:"title"`
+	ResponsibleRoles       []oscalResponsibleRole       `json:"responsible-roles" yaml:"responsible-roles"`
+	ControlImplementations []oscalControlImplementation `json:"control-implementations" yaml:"control-implementations"`
+}
+
+type oscalComponentDefinition struct {
+	Metadata struct {
+		Roles []oscalRole `json:"roles" yaml:"roles"`
+	} `json:"metadata" yaml:"metadata"`
+	Components []oscalComponent `json:"components" yaml:"components"`
+}
+
+type oscalDocument struct {
+	ComponentDefinition oscalComponentDefinition `json:"component-definition" yaml:"component-definition"`
+}
+
+// oscalControlRegex matches the OSCAL control IDs, e.g. `ac-2.1`.
+var oscalControlRegex = regexp.MustCompile(`^([a-z]{2})-(\d+)(?:\.(\d+))?$`)
+
+// controlFromOSCAL converts the OSCAL control ID to the control, as it is written in the OpenControls, e.g. `ac-2.1`
+// to `AC-2 (1)`.
+func controlFromOSCAL(controlID string) string {
+	subMatches := oscalControlRegex.FindStringSubmatch(controlID)
+	if subMatches == nil {
+		return strings.ToUpper(controlID)
+	}
+	control := strings.ToUpper(subMatches[1]) + "-" + subMatches[2]
+	if subMatches[3] != "" {
+		control += fmt.Sprintf(" (%s)", subMatches[3])
+	}
+	return control
+}
+
+// sectionKey returns the key of the statement or parameter ID, which is the part after the separator, e.g. `a` for
+// `ac-2_smt.a` with `_smt.`.
+func sectionKey(id, separator string) string {
+	idx := strings.Index(id, separator)
+	if idx < 0 {
+		return ""
+	}
+	return id[idx+len(separator):]
+}
+
+// roleTitles returns the titles of the roles, falling back to the ID for the roles that aren't in the metadata.
+func roleTitles(roles []oscalResponsibleRole, titles map[string]string) string {
+	names := []string{}
+	for _, role := range roles {
+		title, found := titles[role.RoleID]
+		if !found || title == "" {
+			title = role.RoleID
+		}
+		names = append(names, title)
+	}
+	return strings.Join(names, ", ")
+}
+
+// newOSCALSatisfies converts the implemented requirement of the component to the justification of its control.
+func newOSCALSatisfies(component oscalComponent, requirement oscalImplementedRequirement,
+	roles map[string]string) ComponentSatisfies {
+	satisfies := ComponentSatisfies{
+		ComponentKey:  component.UUID,
+		ComponentName: component.Title,
+		Satisfies:     NewSatisfies(controlFromOSCAL(requirement.ControlID)),
+	}
+	satisfies.ResponsibleRole = roleTitles(requirement.ResponsibleRoles, roles)
+	if satisfies.ResponsibleRole == "" {
+		satisfies.ResponsibleRole = roleTitles(component.ResponsibleRoles, roles)
+	}
+
+	for _, statement := range requirement.Statements {
+		satisfies.Narrative = append(satisfies.Narrative, Section{
+			Key:  sectionKey(statement.StatementID, "_smt."),
+			Text: statement.Description,
+		})
+	}
+	// the description is the narrative of the whole control when there aren't any statements.
+	if len(satisfies.Narrative) == 0 && requirement.Description != "" {
+		satisfies.Narrative = append(satisfies.Narrative, Section{Text: requirement.Description})
+	}
+	for _, parameter := range requirement.SetParameters {
+		satisfies.Parameters = append(satisfies.Parameters, Section{
+			Key:  sectionKey(parameter.ParamID, "_prm_"),
+			Text: strings.Join(parameter.Values, ", "),
+		})
+	}
+
+	origins := []string{}
+	statuses := []string{}
+	for _, prop := range requirement.Props {
+		switch prop.Name {
+		case "control-origination":
+			for _, mapping := range origin.GetSourceMappings() {
+				if mapping.IsOSCALMappingEqualTo(prop.Value) {
+					origins = appendNonEmpty(origins, mapping[source.YAML])
+				}
+			}
+		case "implementation-status":
+			for _, mapping := range implementation.GetSourceMappings() {
+				if mapping.IsOSCALMappingEqualTo(prop.Value) {
+					statuses = appendNonEmpty(statuses, mapping[source.YAML])
+				}
+			}
+		}
+	}
+	satisfies.SetControlOrigins(origins)
+	satisfies.SetImplementationStatuses(statuses)
+	return satisfies
+}
+
+// parseComponentDefinition parses the OSCAL component definition in JSON or YAML, depending on the extension.
+func parseComponentDefinition(path string, content []byte) (oscalComponentDefinition, error) {
+	var document oscalDocument
+	var err error
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(content, &document)
+	} else {
+		err = yaml.Unmarshal(content, &document)
+	}
+	if err != nil {
+		return document.ComponentDefinition, fmt.Errorf("unable to parse %s: %s", path, err)
+	}
+	return document.ComponentDefinition, nil
+}
+
+// isComponentDefinitionFile returns whether the file is an OSCAL document, based on its extension.
+func isComponentDefinitionFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// LoadOSCALFrom creates a Source from the OSCAL component definitions (in JSON or YAML) in the provided directory
+// and its subdirectories. The controls of the component definitions are treated as NIST-800-53 controls.
+func LoadOSCALFrom(dirPath string) (Source, []error) {
+	justifications := newJustificationSource()
+	var errors []error
+	walkErr := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isComponentDefinitionFile(path) {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			errors = append(errors, err)
+			return nil
+		}
+		definition, err := parseComponentDefinition(path, content)
+		if err != nil {
+			errors = append(errors, err)
+			return nil
+		}
+
+		roles := map[string]string{}
+		for _, role := range definition.Metadata.Roles {
+			roles[role.ID] = role.Title
+		}
+		for _, component := range definition.Components {
+			for _, implementation := range component.ControlImplementations {
+				for _, requirement := range implementation.ImplementedRequirements {
+					justifications.add(newOSCALSatisfies(component, requirement, roles))
+				}
+			}
+		}
+		return nil
+	})
+	if walkErr != nil {
+		errors = append(errors, walkErr)
+	}
+	return justifications, errors
+}
@@ -0,0 +1,83 @@
+package opencontrols_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func loadOSCALFixture() opencontrols.Source {
+	source, errors := opencontrols.LoadOSCALFrom(fixtures.FixturePath("oscal"))
+	Expect(errors).To(BeEmpty())
+	return source
+}
+
+var _ = Describe("LoadOSCALFrom", func() {
+	It("reads the component definitions in JSON and YAML", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		Expect(data.GetControls()).To(Equal([]string{"AC-2", "AC-2 (1)"}))
+	})
+
+	It("returns the statements as the narrative sections", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		Expect(data.GetNarrative("AC-2", "a")).To(Equal("Amazon Simple Storage Service\nBucket access is managed with IAM for AC-2\n"))
+		Expect(data.GetNarrativeKeys("AC-2")).To(Equal([]string{"a"}))
+	})
+
+	It("returns the description as the narrative when there aren't any statements", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		Expect(data.GetNarrative("AC-2 (1)", "")).To(Equal("Amazon CloudTrail\nAccount activity is logged with CloudTrail\n"))
+	})
+
+	It("returns the values of the set parameters", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		Expect(data.GetParameter("AC-2", "a")).To(Equal("bucket owners, IAM users\n"))
+	})
+
+	It("returns the titles of the responsible roles", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		Expect(data.GetResponsibleRoles("AC-2")).To(Equal("Amazon Simple Storage Service: Storage Administrator\n"))
+		// roles that aren't in the metadata fall back to their ID.
+		Expect(data.GetResponsibleRoles("AC-2 (1)")).To(Equal("Amazon CloudTrail: security-team\n"))
+	})
+
+	It("converts the FedRAMP properties to the OpenControl values", func() {
+		data := opencontrols.NewData(loadOSCALFixture())
+		result := data.GetComponentSatisfies("AC-2")
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].ComponentKey).To(Equal("6f2d5b8e-0a4c-4b6e-8a0f-3d2c1b9e7a10"))
+		Expect(result[0].AllControlOrigins()).To(Equal([]string{"service_provided_system_specific"}))
+		Expect(result[0].AllImplementationStatuses()).To(Equal([]string{"partial"}))
+	})
+
+	It("returns an error for an invalid component definition", func() {
+		dir, err := ioutil.TempDir("", "oscal")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		err = ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, errors := opencontrols.LoadOSCALFrom(dir)
+		Expect(errors).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("AddSource", func() {
+	It("combines the components of the sources", func() {
+		data := fixtures.LoadOpenControlFixture()
+		data.AddSource(loadOSCALFixture())
+
+		Expect(data.GetNarrative("AC-2", "a")).To(Equal(
+			"Amazon Elastic Compute Cloud\nJustification in narrative form A for AC-2\n" +
+				"Amazon Simple Storage Service\nBucket access is managed with IAM for AC-2\n"))
+		Expect(data.GetComponentSatisfies("AC-2")).To(HaveLen(2))
+		origins := data.GetControlOrigins("AC-2")
+		Expect(origins.GetCheckedOrigins().Size()).To(Equal(2))
+	})
+})
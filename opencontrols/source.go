@@ -0,0 +1,398 @@
+package opencontrols
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/commands/docs/docx"
+	"github.com/opencontrol/compliance-masonry/models/components/versions/base"
+)
+
+// Source provides the justifications of the controls of a standard, e.g. from OpenControl YAML or OSCAL component
+// definitions.
+type Source interface {
+	// Standards returns the standards that are satisfied by any of the components, in any order.
+	Standards() []string
+	// Controls returns the controls that are satisfied by any of the components, in any order.
+	Controls(standard string) []string
+	// ResponsibleRoles returns the name and responsible role of each component for the control, one per line, e.g.
+	// `Amazon Elastic Compute Cloud: AWS Staff`.
+	ResponsibleRoles(standard, control string) string
+	// Parameter returns the parameter section of each component for the control, one per line.
+	Parameter(standard, control, sectionKey string) string
+	// Narrative returns the name of each component for the control, followed by its narrative section.
+	Narrative(standard, control, sectionKey string) string
+	// ControlOrigins returns the control origins of each component for the control, as OpenControl YAML values.
+	ControlOrigins(standard, control string) []string
+	// ImplementationStatuses returns the implementation statuses of each component for the control, as OpenControl
+	// YAML values.
+	ImplementationStatuses(standard, control string) []string
+	// ComponentSatisfies returns the justification of each component for the control.
+	ComponentSatisfies(standard, control string) []ComponentSatisfies
+}
+
+// ComponentSatisfies is the justification of a control by a single component.
+type ComponentSatisfies struct {
+	ComponentKey  string
+	ComponentName string
+	// ResponsibleRole is the one of the component, unless the control overrides it.
+	ResponsibleRole string
+	Satisfies
+}
+
+// masonrySource is the Source for OpenControl YAML, which is loaded and formatted by compliance-masonry.
+type masonrySource struct {
+	ocd docx.OpenControlDocx
+	// extensions override the responsible roles of the components for single controls.
+	extensions []componentExtensions
+}
+
+// controlResponsibleRole returns the responsible role that the component sets for the control, if any.
+func (s masonrySource) controlResponsibleRole(componentKey, standard, control string) string {
+	for _, component := range s.extensions {
+		if component.Key == componentKey {
+			return component.controlResponsibleRole(standard, control)
+		}
+	}
+	return ""
+}
+
+func (s masonrySource) Standards() []string {
+	found := map[string]bool{}
+	standards := []string{}
+	for _, component := range s.ocd.Components.GetAll() {
+		for _, satisfies := range component.GetAllSatisfies() {
+			standard := satisfies.GetStandardKey()
+			if !found[standard] {
+				found[standard] = true
+				standards = append(standards, standard)
+			}
+		}
+	}
+	return standards
+}
+
+func (s masonrySource) Controls(standard string) []string {
+	found := map[string]bool{}
+	controls := []string{}
+	for _, component := range s.ocd.Components.GetAll() {
+		for _, satisfies := range component.GetAllSatisfies() {
+			control := satisfies.GetControlKey()
+			if satisfies.GetStandardKey() == standard && !found[control] {
+				found[control] = true
+				controls = append(controls, control)
+			}
+		}
+	}
+	return controls
+}
+
+// ResponsibleRoles doesn't use the formatting of compliance-masonry, which leaves out the names of the components.
+func (s masonrySource) ResponsibleRoles(standard, control string) string {
+	justifications := s.ComponentSatisfies(standard, control)
+	if len(justifications) == 0 {
+		return noInformation(standard, control)
+	}
+	return formatResponsibleRoles(justifications)
+}
+
+func (s masonrySource) Parameter(standard, control, sectionKey string) string {
+	return s.ocd.FormatParameter(standard, control, sectionKey)
+}
+
+func (s masonrySource) Narrative(standard, control, sectionKey string) string {
+	return s.ocd.FormatNarrative(standard, control, sectionKey)
+}
+
+func (s masonrySource) ControlOrigins(standard, control string) []string {
+	origins := []string{}
+	for _, justification := range s.ocd.Justifications.Get(standard, control) {
+		origins = append(origins, justification.SatisfiesData.GetControlOrigin())
+	}
+	return origins
+}
+
+func (s masonrySource) ImplementationStatuses(standard, control string) []string {
+	statuses := []string{}
+	for _, justification := range s.ocd.Justifications.Get(standard, control) {
+		statuses = append(statuses, justification.SatisfiesData.GetImplementationStatus())
+	}
+	return statuses
+}
+
+// convertSections converts the narrative or parameter sections of compliance-masonry.
+func convertSections(sections []base.Section) []Section {
+	converted := []Section{}
+	for _, section := range sections {
+		converted = append(converted, Section{Key: section.GetKey(), Text: section.GetText()})
+	}
+	return converted
+}
+
+// appendNonEmpty appends the value to the values, unless it's empty or already one of them.
+func appendNonEmpty(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func (s masonrySource) ComponentSatisfies(standard, control string) []ComponentSatisfies {
+	result := []ComponentSatisfies{}
+	for _, justification := range s.ocd.Justifications.Get(standard, control) {
+		satisfies := justification.SatisfiesData
+		componentSatisfies := ComponentSatisfies{
+			ComponentKey: justification.ComponentKey,
+			Satisfies:    Satisfies{ControlKey: control, StandardKey: standard},
+		}
+		if component := s.ocd.Components.Get(justification.ComponentKey); component != nil {
+			componentSatisfies.ComponentName = component.GetName()
+			componentSatisfies.ResponsibleRole = component.GetResponsibleRole()
+		}
+		if role := s.controlResponsibleRole(justification.ComponentKey, standard, control); role != "" {
+			componentSatisfies.ResponsibleRole = role
+		}
+		componentSatisfies.Narrative = convertSections(satisfies.GetNarratives())
+		componentSatisfies.Parameters = convertSections(satisfies.GetParameters())
+		componentSatisfies.SetControlOrigins(
+			appendNonEmpty(satisfies.GetControlOrigins(), satisfies.GetControlOrigin()))
+		componentSatisfies.SetImplementationStatuses(
+			appendNonEmpty(satisfies.GetImplementationStatuses(), satisfies.GetImplementationStatus()))
+		result = append(result, componentSatisfies)
+	}
+	return result
+}
+
+// noInformation is the text for a control that none of the components satisfy, which is the same as the one of
+// compliance-masonry.
+func noInformation(standard, control string) string {
+	return fmt.Sprintf("No information found for the combination of standard %s and control %s", standard, control)
+}
+
+// formatResponsibleRoles returns the name and responsible role of each of the components that have one, one per line.
+func formatResponsibleRoles(justifications []ComponentSatisfies) string {
+	text := ""
+	for _, justification := range justifications {
+		if justification.ResponsibleRole == "" {
+			continue
+		}
+		if justification.ComponentName != "" {
+			text += justification.ComponentName + ": "
+		}
+		text += justification.ResponsibleRole + "\n"
+	}
+	return text
+}
+
+// HasInformation returns whether the text from a Source has any information, i.e. it isn't empty or the text for a
+// control that none of the components satisfy.
+func HasInformation(text string) bool {
+	text = strings.TrimSpace(text)
+	return text != "" && !strings.HasPrefix(text, "No information found for the combination of standard")
+}
+
+// justificationSource is a Source for justifications that are already loaded, e.g. from OSCAL. It formats them in the
+// same way as compliance-masonry.
+type justificationSource struct {
+	// justifications are keyed by standard and control, in the order of the components.
+	justifications map[string]map[string][]ComponentSatisfies
+}
+
+func newJustificationSource() *justificationSource {
+	return &justificationSource{justifications: map[string]map[string][]ComponentSatisfies{}}
+}
+
+func (s *justificationSource) add(justification ComponentSatisfies) {
+	controls, exists := s.justifications[justification.StandardKey]
+	if !exists {
+		controls = map[string][]ComponentSatisfies{}
+		s.justifications[justification.StandardKey] = controls
+	}
+	controls[justification.ControlKey] = append(controls[justification.ControlKey], justification)
+}
+
+func (s *justificationSource) Standards() []string {
+	standards := []string{}
+	for standard := range s.justifications {
+		standards = append(standards, standard)
+	}
+	return standards
+}
+
+func (s *justificationSource) Controls(standard string) []string {
+	controls := []string{}
+	for control := range s.justifications[standard] {
+		controls = append(controls, control)
+	}
+	return controls
+}
+
+// findSection returns the text of the section with the key, and whether it was found.
+func findSection(sections []Section, key string) (string, bool) {
+	for _, section := range sections {
+		if section.Key == key {
+			return section.Text, true
+		}
+	}
+	return "", false
+}
+
+func (s *justificationSource) ResponsibleRoles(standard, control string) string {
+	justifications := s.ComponentSatisfies(standard, control)
+	if len(justifications) == 0 {
+		return noInformation(standard, control)
+	}
+	return formatResponsibleRoles(justifications)
+}
+
+func (s *justificationSource) Parameter(standard, control, sectionKey string) string {
+	justifications := s.ComponentSatisfies(standard, control)
+	if len(justifications) == 0 {
+		return noInformation(standard, control)
+	}
+	text := ""
+	for _, justification := range justifications {
+		if parameter, found := findSection(justification.Parameters, sectionKey); found {
+			text += parameter + "\n"
+		}
+	}
+	return text
+}
+
+func (s *justificationSource) Narrative(standard, control, sectionKey string) string {
+	justifications := s.ComponentSatisfies(standard, control)
+	if len(justifications) == 0 {
+		return noInformation(standard, control)
+	}
+	text := ""
+	for _, justification := range justifications {
+		if narrative, found := findSection(justification.Narrative, sectionKey); found {
+			text += fmt.Sprintf("%s\n%s\n", justification.ComponentName, narrative)
+		}
+	}
+	return text
+}
+
+func (s *justificationSource) ControlOrigins(standard, control string) []string {
+	origins := []string{}
+	for _, justification := range s.ComponentSatisfies(standard, control) {
+		origins = append(origins, justification.AllControlOrigins()...)
+	}
+	return origins
+}
+
+func (s *justificationSource) ImplementationStatuses(standard, control string) []string {
+	statuses := []string{}
+	for _, justification := range s.ComponentSatisfies(standard, control) {
+		statuses = append(statuses, justification.AllImplementationStatuses()...)
+	}
+	return statuses
+}
+
+func (s *justificationSource) ComponentSatisfies(standard, control string) []ComponentSatisfies {
+	return s.justifications[standard][control]
+}
+
+// multiSource combines the components of several Sources, e.g. to fill an SSP from both OpenControl YAML and OSCAL.
+type multiSource []Source
+
+// Combine returns a Source with the components of all of the sources, in order.
+func Combine(sources ...Source) Source {
+	return multiSource(sources)
+}
+
+// satisfying returns the sources that have a justification for the control, or the first source if none of them do,
+// so that its text for missing information is used.
+func (m multiSource) satisfying(standard, control string) []Source {
+	sources := []Source{}
+	for _, source := range m {
+		if len(source.ComponentSatisfies(standard, control)) > 0 {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 && len(m) > 0 {
+		sources = append(sources, m[0])
+	}
+	return sources
+}
+
+func (m multiSource) Standards() []string {
+	found := map[string]bool{}
+	standards := []string{}
+	for _, source := range m {
+		for _, standard := range source.Standards() {
+			if !found[standard] {
+				found[standard] = true
+				standards = append(standards, standard)
+			}
+		}
+	}
+	return standards
+}
+
+func (m multiSource) Controls(standard string) []string {
+	found := map[string]bool{}
+	controls := []string{}
+	for _, source := range m {
+		for _, control := range source.Controls(standard) {
+			if !found[control] {
+				found[control] = true
+				controls = append(controls, control)
+			}
+		}
+	}
+	return controls
+}
+
+func (m multiSource) ResponsibleRoles(standard, control string) string {
+	text := ""
+	for _, source := range m.satisfying(standard, control) {
+		text += source.ResponsibleRoles(standard, control)
+	}
+	return text
+}
+
+func (m multiSource) Parameter(standard, control, sectionKey string) string {
+	text := ""
+	for _, source := range m.satisfying(standard, control) {
+		text += source.Parameter(standard, control, sectionKey)
+	}
+	return text
+}
+
+func (m multiSource) Narrative(standard, control, sectionKey string) string {
+	text := ""
+	for _, source := range m.satisfying(standard, control) {
+		text += source.Narrative(standard, control, sectionKey)
+	}
+	return text
+}
+
+func (m multiSource) ControlOrigins(standard, control string) []string {
+	origins := []string{}
+	for _, source := range m {
+		origins = append(origins, source.ControlOrigins(standard, control)...)
+	}
+	return origins
+}
+
+func (m multiSource) ImplementationStatuses(standard, control string) []string {
+	statuses := []string{}
+	for _, source := range m {
+		statuses = append(statuses, source.ImplementationStatuses(standard, control)...)
+	}
+	return statuses
+}
+
+func (m multiSource) ComponentSatisfies(standard, control string) []ComponentSatisfies {
+	result := []ComponentSatisfies{}
+	for _, source := range m {
+		result = append(result, source.ComponentSatisfies(standard, control)...)
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package opencontrols
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Contact is a person that is responsible for the information system, e.g. the Information System Owner.
+type Contact struct {
+	Name         string `yaml:"name"`
+	Title        string `yaml:"title"`
+	Organization string `yaml:"organization"`
+	Address      string `yaml:"address"`
+	Phone        string `yaml:"phone"`
+	Email        string `yaml:"email"`
+}
+
+// System contains the metadata of the information system that the SSP is for, which is read from a `system.yaml`.
+type System struct {
+	Name         string `yaml:"name"`
+	Abbreviation string `yaml:"abbreviation"`
+	// Identifier is the unique identifier of the information system, e.g. the FedRAMP package ID.
+	Identifier string `yaml:"identifier"`
+	// Categorization is the FIPS-199 security categorization, e.g. `Moderate`.
+	Categorization  string  `yaml:"categorization"`
+	ServiceModel    string  `yaml:"service_model"`
+	DeploymentModel string  `yaml:"deployment_model"`
+	Owner           Contact `yaml:"owner"`
+	// ISSO is the Information System Security Officer.
+	ISSO                 Contact   `yaml:"isso"`
+	AuthorizingOfficials []Contact `yaml:"authorizing_officials"`
+	// Contacts are the other contacts at the CSP, keyed by the title of their table in the SSP, e.g.
+	// `Information System Management Point of Contact`.
+	Contacts map[string]Contact `yaml:"contacts"`
+	Version  string             `yaml:"version"`
+	Date     string             `yaml:"date"`
+	// ImportProfile is the link to the OSCAL profile of the baseline, e.g. the FedRAMP Moderate one, for the
+	// `import-profile` of an OSCAL SSP.
+	ImportProfile string `yaml:"import_profile"`
+	// Commit is the commit of the OpenControls that the SSP is filled from.
+	Commit string `yaml:"commit"`
+	// Properties are additional custom properties of the document, keyed by name.
+	Properties map[string]string `yaml:"properties"`
+	// Placeholders are additional placeholders in the headers and footers of the document (e.g. `<Agency>`), with
+	// their values.
+	Placeholders map[string]string `yaml:"placeholders"`
+}
+
+// LoadSystem reads the System from the YAML file at the provided path.
+func LoadSystem(path string) (System, error) {
+	var system System
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return system, err
+	}
+	err = yaml.Unmarshal(content, &system)
+	if err != nil {
+		return system, fmt.Errorf("unable to parse %s: %s", path, err)
+	}
+	return system, nil
+}
@@ -0,0 +1,38 @@
+package opencontrols_test
+
+import (
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	. "github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("System", func() {
+	Describe("LoadSystem", func() {
+		It("reads the metadata of the system", func() {
+			system, err := LoadSystem(fixtures.FixturePath("system.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(system.Name).To(Equal("Amazon Web Services"))
+			Expect(system.Version).To(Equal("1.2"))
+			Expect(system.Date).To(Equal("2016-08-01"))
+			Expect(system.Commit).To(Equal("8c1d2a6"))
+			Expect(system.Properties).To(HaveKeyWithValue("Agency", "Department of Examples"))
+		})
+
+		It("reads the contacts of the system", func() {
+			system, err := LoadSystem(fixtures.FixturePath("system.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(system.Owner.Name).To(Equal("Jane Doe"))
+			Expect(system.Owner.Email).To(Equal("jane.doe@example.com"))
+			Expect(system.AuthorizingOfficials).To(HaveLen(2))
+			Expect(system.AuthorizingOfficials[1].Name).To(Equal("Bob Jones"))
+			Expect(system.Contacts).To(HaveKey("Information System Management Point of Contact"))
+		})
+
+		It("gives an error when the file isn't found", func() {
+			_, err := LoadSystem("non-existent.yaml")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
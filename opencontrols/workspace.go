@@ -0,0 +1,151 @@
+package opencontrols
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// componentSchemaVersion is the version of the OpenControl component schema that is written.
+const componentSchemaVersion = "3.1.0"
+
+// Section contains the key and text for a particular narrative or parameter section.
+type Section struct {
+	Key  string `yaml:"key,omitempty"`
+	Text string `yaml:"text"`
+}
+
+// Satisfies contains the justification for a single control of a component.
+// A single control origin or implementation status is written in the singular field, for compatibility with older schemas.
+type Satisfies struct {
+	ControlKey             string    `yaml:"control_key"`
+	StandardKey            string    `yaml:"standard_key"`
+	Narrative              []Section `yaml:"narrative,omitempty"`
+	Parameters             []Section `yaml:"parameters,omitempty"`
+	ControlOrigin          string    `yaml:"control_origin,omitempty"`
+	ControlOrigins         []string  `yaml:"control_origins,omitempty"`
+	ImplementationStatus   string    `yaml:"implementation_status,omitempty"`
+	ImplementationStatuses []string  `yaml:"implementation_statuses,omitempty"`
+	// ResponsibleRole overrides the one of the component for this control. It isn't part of the OpenControl schema.
+	ResponsibleRole string `yaml:"responsible_role,omitempty"`
+}
+
+// NewSatisfies creates the justification of the control for the default standard.
+func NewSatisfies(control string) Satisfies {
+	return Satisfies{ControlKey: control, StandardKey: standardKey}
+}
+
+// SetControlOrigins sets the control origins, using the singular field when there is only one.
+func (s *Satisfies) SetControlOrigins(origins []string) {
+	s.ControlOrigin, s.ControlOrigins = "", nil
+	if len(origins) == 1 {
+		s.ControlOrigin = origins[0]
+	} else if len(origins) > 1 {
+		s.ControlOrigins = origins
+	}
+}
+
+// SetImplementationStatuses sets the implementation statuses, using the singular field when there is only one.
+func (s *Satisfies) SetImplementationStatuses(statuses []string) {
+	s.ImplementationStatus, s.ImplementationStatuses = "", nil
+	if len(statuses) == 1 {
+		s.ImplementationStatus = statuses[0]
+	} else if len(statuses) > 1 {
+		s.ImplementationStatuses = statuses
+	}
+}
+
+// AllControlOrigins returns the control origins, whether there is one or more.
+func (s Satisfies) AllControlOrigins() []string {
+	if s.ControlOrigin != "" {
+		return []string{s.ControlOrigin}
+	}
+	return s.ControlOrigins
+}
+
+// AllImplementationStatuses returns the implementation statuses, whether there is one or more.
+func (s Satisfies) AllImplementationStatuses() []string {
+	if s.ImplementationStatus != "" {
+		return []string{s.ImplementationStatus}
+	}
+	return s.ImplementationStatuses
+}
+
+// Component is an OpenControl component that can be written as a `component.yaml`.
+type Component struct {
+	Name            string      `yaml:"name"`
+	Key             string      `yaml:"key"`
+	SchemaVersion   string      `yaml:"schema_version"`
+	ResponsibleRole string      `yaml:"responsible_role,omitempty"`
+	Satisfies       []Satisfies `yaml:"satisfies"`
+}
+
+// NewComponent creates an empty Component.
+func NewComponent(name, key string) Component {
+	return Component{Name: name, Key: key, SchemaVersion: componentSchemaVersion, Satisfies: []Satisfies{}}
+}
+
+// Certification is an OpenControl certification that lists the controls of each standard.
+type Certification struct {
+	Name      string                         `yaml:"name"`
+	Standards map[string]map[string]struct{} `yaml:"standards"`
+}
+
+// NewCertification creates a Certification for the controls of the default standard.
+func NewCertification(name string, controls []string) Certification {
+	standardControls := map[string]struct{}{}
+	for _, control := range controls {
+		standardControls[control] = struct{}{}
+	}
+	return Certification{
+		Name:      name,
+		Standards: map[string]map[string]struct{}{standardKey: standardControls},
+	}
+}
+
+// LoadCertification reads the Certification from the YAML file at the provided path, e.g. a `certifications/LATO.yaml`.
+func LoadCertification(path string) (Certification, error) {
+	var certification Certification
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return certification, err
+	}
+	err = yaml.Unmarshal(content, &certification)
+	if err != nil {
+		return certification, fmt.Errorf("unable to parse %s: %s", path, err)
+	}
+	return certification, nil
+}
+
+// HasControl returns whether the control of the standard is in the certification.
+func (c Certification) HasControl(standard, control string) bool {
+	_, found := c.Standards[standard][control]
+	return found
+}
+
+func writeYAML(path string, value interface{}) error {
+	content, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// WriteWorkspace writes the component and certification to the provided `opencontrols/` directory, in the same layout
+// that LoadFrom reads.
+func WriteWorkspace(dirPath string, component Component, certification Certification) error {
+	componentPath := filepath.Join(dirPath, "components", component.Key, "component.yaml")
+	err := writeYAML(componentPath, component)
+	if err != nil {
+		return err
+	}
+	certificationPath := filepath.Join(dirPath, "certifications", certification.Name+".yaml")
+	return writeYAML(certificationPath, certification)
+}
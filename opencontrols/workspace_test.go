@@ -0,0 +1,52 @@
+package opencontrols_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/opencontrol/fedramp-templater/opencontrols"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Workspace", func() {
+	Describe("WriteWorkspace", func() {
+		It("writes the component and certification in the OpenControl layout", func() {
+			dir, err := ioutil.TempDir("", "workspace")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			component := NewComponent("My System", "my_system")
+			satisfies := NewSatisfies("AC-2")
+			satisfies.Narrative = []Section{{Key: "a", Text: "Justification for AC-2"}}
+			satisfies.SetControlOrigins([]string{"shared"})
+			component.Satisfies = append(component.Satisfies, satisfies)
+			certification := NewCertification("FedRAMP", []string{"AC-2"})
+
+			err = WriteWorkspace(dir, component, certification)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, "components", "my_system", "component.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			written := Component{}
+			Expect(yaml.Unmarshal(content, &written)).To(Succeed())
+			Expect(written).To(Equal(component))
+
+			content, err = ioutil.ReadFile(filepath.Join(dir, "certifications", "FedRAMP.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("NIST-800-53:\n    AC-2: {}\n"))
+		})
+	})
+
+	Describe("SetControlOrigins", func() {
+		It("uses the list when there are multiple origins", func() {
+			satisfies := NewSatisfies("AC-2")
+			satisfies.SetControlOrigins([]string{"shared", "inherited"})
+			Expect(satisfies.ControlOrigin).To(BeEmpty())
+			Expect(satisfies.ControlOrigins).To(Equal([]string{"shared", "inherited"}))
+		})
+	})
+})
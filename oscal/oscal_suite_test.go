@@ -0,0 +1,13 @@
+package oscal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOSCAL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OSCAL Suite")
+}
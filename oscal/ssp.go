@@ -0,0 +1,470 @@
+package oscal
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+)
+
+const (
+	oscalVersion = "1.0.4"
+	// fedrampNamespace is the namespace of the FedRAMP extensions to OSCAL.
+	fedrampNamespace = "https://fedramp.gov/ns/oscal"
+	// uuidNamespace is the prefix of the names that the UUIDs are generated from.
+	uuidNamespace = "https://github.com/opencontrol/fedramp-templater/"
+	// fedrampIdentifierType is the type of the FedRAMP ID (e.g. `F1234567890`) of the system.
+	fedrampIdentifierType = "https://fedramp.gov"
+	// defaultImportProfile is the placeholder for the link to the profile of the baseline, when there isn't one.
+	defaultImportProfile = "profile.json"
+	// defaultImpactLevel is the FIPS-199 impact level when the categorization of the system isn't known.
+	defaultImpactLevel = "fips-199-moderate"
+)
+
+type prop struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns"`
+	Value string `json:"value"`
+}
+
+type role struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type metadata struct {
+	Title        string `json:"title"`
+	LastModified string `json:"last-modified"`
+	Version      string `json:"version"`
+	OSCALVersion string `json:"oscal-version"`
+	Roles        []role `json:"roles,omitempty"`
+}
+
+type status struct {
+	State string `json:"state"`
+}
+
+type component struct {
+	UUID        string `json:"uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      status `json:"status"`
+}
+
+type user struct {
+	UUID    string   `json:"uuid"`
+	Title   string   `json:"title"`
+	RoleIDs []string `json:"role-ids,omitempty"`
+}
+
+type systemImplementation struct {
+	Users      []user      `json:"users"`
+	Components []component `json:"components"`
+}
+
+type importProfile struct {
+	Href string `json:"href"`
+}
+
+type systemID struct {
+	IdentifierType string `json:"identifier-type"`
+	ID             string `json:"id"`
+}
+
+type impact struct {
+	Base string `json:"base"`
+}
+
+type informationType struct {
+	UUID                  string `json:"uuid"`
+	Title                 string `json:"title"`
+	Description           string `json:"description"`
+	ConfidentialityImpact impact `json:"confidentiality-impact"`
+	IntegrityImpact       impact `json:"integrity-impact"`
+	AvailabilityImpact    impact `json:"availability-impact"`
+}
+
+type systemInformation struct {
+	InformationTypes []informationType `json:"information-types"`
+}
+
+type securityImpactLevel struct {
+	Confidentiality string `json:"security-objective-confidentiality"`
+	Integrity       string `json:"security-objective-integrity"`
+	Availability    string `json:"security-objective-availability"`
+}
+
+type authorizationBoundary struct {
+	Description string `json:"description"`
+}
+
+type systemCharacteristics struct {
+	SystemIDs                []systemID            `json:"system-ids"`
+	SystemName               string                `json:"system-name"`
+	SystemNameShort          string                `json:"system-name-short,omitempty"`
+	Description              string                `json:"description"`
+	SecuritySensitivityLevel string                `json:"security-sensitivity-level"`
+	SystemInformation        systemInformation     `json:"system-information"`
+	SecurityImpactLevel      securityImpactLevel   `json:"security-impact-level"`
+	Status                   status                `json:"status"`
+	AuthorizationBoundary    authorizationBoundary `json:"authorization-boundary"`
+}
+
+type setParameter struct {
+	ParamID string   `json:"param-id"`
+	Values  []string `json:"values"`
+}
+
+type responsibleRole struct {
+	RoleID string `json:"role-id"`
+}
+
+type byComponent struct {
+	ComponentUUID string `json:"component-uuid"`
+	UUID          string `json:"uuid"`
+	Description   string `json:"description"`
+}
+
+type statement struct {
+	StatementID  string        `json:"statement-id"`
+	UUID         string        `json:"uuid"`
+	ByComponents []byComponent `json:"by-components"`
+}
+
+type implementedRequirement struct {
+	UUID             string            `json:"uuid"`
+	ControlID        string            `json:"control-id"`
+	Props            []prop            `json:"props,omitempty"`
+	SetParameters    []setParameter    `json:"set-parameters,omitempty"`
+	ResponsibleRoles []responsibleRole `json:"responsible-roles,omitempty"`
+	Statements       []statement       `json:"statements,omitempty"`
+	ByComponents     []byComponent     `json:"by-components,omitempty"`
+}
+
+type controlImplementation struct {
+	Description             string                   `json:"description"`
+	ImplementedRequirements []implementedRequirement `json:"implemented-requirements"`
+}
+
+type systemSecurityPlan struct {
+	UUID                  string                `json:"uuid"`
+	Metadata              metadata              `json:"metadata"`
+	ImportProfile         importProfile         `json:"import-profile"`
+	SystemCharacteristics systemCharacteristics `json:"system-characteristics"`
+	SystemImplementation  systemImplementation  `json:"system-implementation"`
+	ControlImplementation controlImplementation `json:"control-implementation"`
+}
+
+type document struct {
+	SystemSecurityPlan systemSecurityPlan `json:"system-security-plan"`
+}
+
+// newUUID returns the name-based (version 5) UUID for the name, so that the same data always gets the same UUIDs.
+func newUUID(name string) string {
+	hash := sha1.Sum([]byte(uuidNamespace + name))
+	hash[6] = (hash[6] & 0x0f) | 0x50
+	hash[8] = (hash[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", hash[0:4], hash[4:6], hash[6:8], hash[8:10], hash[10:16])
+}
+
+// controlRegex matches the controls, as they are written in the OpenControls, e.g. `AC-2 (1)`.
+var controlRegex = regexp.MustCompile(`^([A-Z]{2})-(\d+)(?: \((\d+)\))?$`)
+
+// ControlID converts the control to the OSCAL control ID, e.g. `AC-2 (1)` to `ac-2.1`.
+func ControlID(control string) string {
+	subMatches := controlRegex.FindStringSubmatch(control)
+	if subMatches == nil {
+		return strings.ToLower(control)
+	}
+	id := strings.ToLower(subMatches[1]) + "-" + subMatches[2]
+	if subMatches[3] != "" {
+		id += "." + subMatches[3]
+	}
+	return id
+}
+
+// roleID converts the responsible role to an OSCAL role ID, e.g. `AWS Staff` to `aws-staff`.
+func roleID(role string) string {
+	words := strings.FieldsFunc(strings.ToLower(role), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
+
+// fedrampProp returns the FedRAMP prop with the name and value.
+func fedrampProp(name, value string) prop {
+	return prop{Name: name, NS: fedrampNamespace, Value: value}
+}
+
+// requirementProps returns the `implementation-status` and `control-origination` props of the justifications, in
+// the order of the checkboxes of the SSP.
+func requirementProps(justifications []opencontrols.ComponentSatisfies) []prop {
+	statusMappings := implementation.GetSourceMappings()
+	originMappings := origin.GetSourceMappings()
+	statuses := map[implementation.Key]bool{}
+	origins := map[origin.Key]bool{}
+	for _, justification := range justifications {
+		for _, value := range justification.AllImplementationStatuses() {
+			for key, mapping := range statusMappings {
+				if mapping.IsYAMLMappingEqualTo(value) {
+					statuses[key] = true
+				}
+			}
+		}
+		for _, value := range justification.AllControlOrigins() {
+			for key, mapping := range originMappings {
+				if mapping.IsYAMLMappingEqualTo(value) {
+					origins[key] = true
+				}
+			}
+		}
+	}
+
+	props := []prop{}
+	for key := implementation.ImplementedImplementation; key <= implementation.NotApplicableImplementation; key++ {
+		if statuses[key] {
+			props = append(props, fedrampProp("implementation-status", statusMappings[key][source.OSCAL]))
+		}
+	}
+	for key := origin.ServiceProviderCorporateOrigination; key <= origin.InheritedOrigination; key++ {
+		if origins[key] {
+			props = append(props, fedrampProp("control-origination", originMappings[key][source.OSCAL]))
+		}
+	}
+	return props
+}
+
+// impactLevel converts the FIPS-199 categorization of the system (e.g. `Moderate`) to the OSCAL impact level, e.g.
+// `fips-199-moderate`.
+func impactLevel(categorization string) string {
+	switch level := strings.ToLower(strings.TrimSpace(categorization)); level {
+	case "low", "moderate", "high":
+		return "fips-199-" + level
+	default:
+		return defaultImpactLevel
+	}
+}
+
+// newSystemCharacteristics returns the system characteristics from the metadata of the system. The ones that aren't
+// set are placeholders, which have to be completed before submitting the SSP.
+func newSystemCharacteristics(system opencontrols.System) systemCharacteristics {
+	name := system.Name
+	if name == "" {
+		name = "Information System"
+	}
+	identifier := system.Identifier
+	if identifier == "" {
+		identifier = "F00000000"
+	}
+	level := impactLevel(system.Categorization)
+	return systemCharacteristics{
+		SystemIDs:                []systemID{{IdentifierType: fedrampIdentifierType, ID: identifier}},
+		SystemName:               name,
+		SystemNameShort:          system.Abbreviation,
+		Description:              name,
+		SecuritySensitivityLevel: level,
+		SystemInformation: systemInformation{InformationTypes: []informationType{{
+			UUID:                  newUUID("information-type"),
+			Title:                 name,
+			Description:           "The information processed by " + name + ".",
+			ConfidentialityImpact: impact{Base: level},
+			IntegrityImpact:       impact{Base: level},
+			AvailabilityImpact:    impact{Base: level},
+		}}},
+		SecurityImpactLevel:   securityImpactLevel{Confidentiality: level, Integrity: level, Availability: level},
+		Status:                status{State: "operational"},
+		AuthorizationBoundary: authorizationBoundary{Description: "The components of " + name + "."},
+	}
+}
+
+// ssp accumulates the system security plan while going through the controls.
+type ssp struct {
+	document   document
+	components map[string]bool
+	roles      map[string]bool
+}
+
+// addComponent adds the component of the justification to the system implementation, unless it's already there.
+func (s *ssp) addComponent(justification opencontrols.ComponentSatisfies) {
+	if s.components[justification.ComponentKey] {
+		return
+	}
+	s.components[justification.ComponentKey] = true
+	title := justification.ComponentName
+	if title == "" {
+		title = justification.ComponentKey
+	}
+	systemImplementation := &s.document.SystemSecurityPlan.SystemImplementation
+	systemImplementation.Components = append(systemImplementation.Components, component{
+		UUID:        newUUID("component/" + justification.ComponentKey),
+		Type:        "service",
+		Title:       title,
+		Description: title,
+		Status:      status{State: "operational"},
+	})
+}
+
+// addRole adds the responsible role to the metadata, and returns its ID.
+func (s *ssp) addRole(title string) string {
+	id := roleID(title)
+	if !s.roles[id] {
+		s.roles[id] = true
+		metadata := &s.document.SystemSecurityPlan.Metadata
+		metadata.Roles = append(metadata.Roles, role{ID: id, Title: title})
+	}
+	return id
+}
+
+// addUsers adds a user to the system implementation for each of the roles, or a single one if there aren't any, as the
+// SSP needs at least one.
+func (s *ssp) addUsers() {
+	systemImplementation := &s.document.SystemSecurityPlan.SystemImplementation
+	for _, role := range s.document.SystemSecurityPlan.Metadata.Roles {
+		systemImplementation.Users = append(systemImplementation.Users, user{
+			UUID:    newUUID("user/" + role.ID),
+			Title:   role.Title,
+			RoleIDs: []string{role.ID},
+		})
+	}
+	if len(systemImplementation.Users) == 0 {
+		systemImplementation.Users = append(systemImplementation.Users, user{
+			UUID:  newUUID("user"),
+			Title: "System User",
+		})
+	}
+}
+
+// addRequirement adds the implemented requirement for the control with the justifications of the components.
+func (s *ssp) addRequirement(control string, justifications []opencontrols.ComponentSatisfies) {
+	controlID := ControlID(control)
+	requirement := implementedRequirement{
+		UUID:      newUUID("requirement/" + control),
+		ControlID: controlID,
+		Props:     requirementProps(justifications),
+	}
+	statements := map[string]*statement{}
+	statementKeys := []string{}
+	parameters := map[string]*setParameter{}
+	parameterKeys := []string{}
+	roles := map[string]bool{}
+
+	for _, justification := range justifications {
+		s.addComponent(justification)
+		componentUUID := newUUID("component/" + justification.ComponentKey)
+		if justification.ResponsibleRole != "" {
+			id := s.addRole(justification.ResponsibleRole)
+			if !roles[id] {
+				roles[id] = true
+				requirement.ResponsibleRoles = append(requirement.ResponsibleRoles, responsibleRole{RoleID: id})
+			}
+		}
+
+		for _, narrative := range justification.Narrative {
+			// the narrative of the whole control goes directly in the requirement.
+			if narrative.Key == "" {
+				requirement.ByComponents = append(requirement.ByComponents, byComponent{
+					ComponentUUID: componentUUID,
+					UUID:          newUUID("by-component/" + control + "/" + justification.ComponentKey),
+					Description:   narrative.Text,
+				})
+				continue
+			}
+			stmt, exists := statements[narrative.Key]
+			if !exists {
+				stmt = &statement{
+					StatementID:  fmt.Sprintf("%s_smt.%s", controlID, narrative.Key),
+					UUID:         newUUID("statement/" + control + "/" + narrative.Key),
+					ByComponents: []byComponent{},
+				}
+				statements[narrative.Key] = stmt
+				statementKeys = append(statementKeys, narrative.Key)
+			}
+			stmt.ByComponents = append(stmt.ByComponents, byComponent{
+				ComponentUUID: componentUUID,
+				UUID:          newUUID("by-component/" + control + "/" + narrative.Key + "/" + justification.ComponentKey),
+				Description:   narrative.Text,
+			})
+		}
+
+		for _, parameter := range justification.Parameters {
+			param, exists := parameters[parameter.Key]
+			if !exists {
+				param = &setParameter{ParamID: fmt.Sprintf("%s_prm_%s", controlID, parameter.Key)}
+				parameters[parameter.Key] = param
+				parameterKeys = append(parameterKeys, parameter.Key)
+			}
+			param.Values = append(param.Values, parameter.Text)
+		}
+	}
+
+	sort.Strings(statementKeys)
+	for _, key := range statementKeys {
+		requirement.Statements = append(requirement.Statements, *statements[key])
+	}
+	sort.Strings(parameterKeys)
+	for _, key := range parameterKeys {
+		requirement.SetParameters = append(requirement.SetParameters, *parameters[key])
+	}
+
+	controlImplementation := &s.document.SystemSecurityPlan.ControlImplementation
+	controlImplementation.ImplementedRequirements = append(controlImplementation.ImplementedRequirements, requirement)
+}
+
+// WriteSSPTo writes the control implementation of the components as an OSCAL system security plan in JSON to the
+// writer. Each control has an implemented requirement, with a statement for each narrative section and a
+// by-component for each component. The FedRAMP `implementation-status` and `control-origination` props are set from
+// the implementation status and control origination of the components. The `import-profile` and
+// `system-characteristics` are filled from the metadata of the system, with placeholders for the fields that it
+// doesn't have, and there is a user for each responsible role.
+func WriteSSPTo(writer io.Writer, data opencontrols.Data, system opencontrols.System, lastModified time.Time) error {
+	title := "System Security Plan"
+	if system.Name != "" {
+		title = system.Name + " " + title
+	}
+	version := system.Version
+	if version == "" {
+		version = "1.0"
+	}
+	href := system.ImportProfile
+	if href == "" {
+		href = defaultImportProfile
+	}
+	s := &ssp{
+		document: document{SystemSecurityPlan: systemSecurityPlan{
+			UUID: newUUID("system-security-plan"),
+			Metadata: metadata{
+				Title:        title,
+				LastModified: lastModified.UTC().Format(time.RFC3339),
+				Version:      version,
+				OSCALVersion: oscalVersion,
+			},
+			ImportProfile:         importProfile{Href: href},
+			SystemCharacteristics: newSystemCharacteristics(system),
+			SystemImplementation:  systemImplementation{Users: []user{}, Components: []component{}},
+			ControlImplementation: controlImplementation{
+				Description:             "The control implementation of the OpenControl components.",
+				ImplementedRequirements: []implementedRequirement{},
+			},
+		}},
+		components: map[string]bool{},
+		roles:      map[string]bool{},
+	}
+	for _, control := range data.GetControls() {
+		s.addRequirement(control, data.GetComponentSatisfies(control))
+	}
+	s.addUsers()
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.document)
+}
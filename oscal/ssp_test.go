@@ -0,0 +1,219 @@
+package oscal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	. "github.com/opencontrol/fedramp-templater/oscal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type byComponent struct {
+	ComponentUUID string `json:"component-uuid"`
+	Description   string `json:"description"`
+}
+
+type ssp struct {
+	SystemSecurityPlan struct {
+		Metadata struct {
+			LastModified string `json:"last-modified"`
+			Roles        []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"roles"`
+		} `json:"metadata"`
+		SystemImplementation struct {
+			Components []struct {
+				UUID  string `json:"uuid"`
+				Title string `json:"title"`
+			} `json:"components"`
+		} `json:"system-implementation"`
+		ControlImplementation struct {
+			ImplementedRequirements []struct {
+				ControlID string `json:"control-id"`
+				Props     []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"props"`
+				SetParameters []struct {
+					ParamID string   `json:"param-id"`
+					Values  []string `json:"values"`
+				} `json:"set-parameters"`
+				ResponsibleRoles []struct {
+					RoleID string `json:"role-id"`
+				} `json:"responsible-roles"`
+				Statements []struct {
+					StatementID  string        `json:"statement-id"`
+					ByComponents []byComponent `json:"by-components"`
+				} `json:"statements"`
+				ByComponents []byComponent `json:"by-components"`
+			} `json:"implemented-requirements"`
+		} `json:"control-implementation"`
+	} `json:"system-security-plan"`
+}
+
+func writeSSPJSON(data opencontrols.Data, system opencontrols.System) []byte {
+	buf := &bytes.Buffer{}
+	err := WriteSSPTo(buf, data, system, time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC))
+	Expect(err).NotTo(HaveOccurred())
+	return buf.Bytes()
+}
+
+func writeSSP(data opencontrols.Data) ssp {
+	var result ssp
+	Expect(json.Unmarshal(writeSSPJSON(data, opencontrols.System{}), &result)).To(Succeed())
+	return result
+}
+
+// writeSSPSections returns the sections of the system security plan (i.e. the objects, not the UUID), keyed by name.
+func writeSSPSections(data opencontrols.Data, system opencontrols.System) map[string]map[string]interface{} {
+	var result struct {
+		SystemSecurityPlan map[string]interface{} `json:"system-security-plan"`
+	}
+	Expect(json.Unmarshal(writeSSPJSON(data, system), &result)).To(Succeed())
+	sections := map[string]map[string]interface{}{}
+	for name, value := range result.SystemSecurityPlan {
+		if section, ok := value.(map[string]interface{}); ok {
+			sections[name] = section
+		}
+	}
+	return sections
+}
+
+var _ = Describe("ControlID", func() {
+	It("converts the controls to OSCAL control IDs", func() {
+		Expect(ControlID("AC-2")).To(Equal("ac-2"))
+		Expect(ControlID("AC-2 (1)")).To(Equal("ac-2.1"))
+	})
+})
+
+var _ = Describe("WriteSSPTo", func() {
+	It("writes an implemented requirement for each control", func() {
+		result := writeSSP(fixtures.LoadOpenControlFixture()).SystemSecurityPlan
+
+		Expect(result.Metadata.LastModified).To(Equal("2016-08-01T12:00:00Z"))
+		Expect(result.Metadata.Roles).To(HaveLen(1))
+		Expect(result.Metadata.Roles[0].ID).To(Equal("aws-staff"))
+		Expect(result.SystemImplementation.Components).To(HaveLen(1))
+		component := result.SystemImplementation.Components[0]
+		Expect(component.Title).To(Equal("Amazon Elastic Compute Cloud"))
+
+		requirements := result.ControlImplementation.ImplementedRequirements
+		Expect(requirements).To(HaveLen(2))
+		Expect(requirements[0].ControlID).To(Equal("ac-2"))
+		Expect(requirements[0].ResponsibleRoles[0].RoleID).To(Equal("aws-staff"))
+		Expect(requirements[0].Props).To(HaveLen(2))
+		Expect(requirements[0].Props[0].Name).To(Equal("implementation-status"))
+		Expect(requirements[0].Props[0].Value).To(Equal("partial"))
+		Expect(requirements[0].Props[1].Name).To(Equal("control-origination"))
+		Expect(requirements[0].Props[1].Value).To(Equal("shared"))
+		Expect(requirements[0].Statements).To(HaveLen(2))
+		Expect(requirements[0].Statements[0].StatementID).To(Equal("ac-2_smt.a"))
+		Expect(requirements[0].Statements[0].ByComponents[0].ComponentUUID).To(Equal(component.UUID))
+		Expect(requirements[0].Statements[0].ByComponents[0].Description).To(Equal("Justification in narrative form A for AC-2"))
+
+		Expect(requirements[1].ControlID).To(Equal("ac-2.1"))
+		Expect(requirements[1].Statements).To(BeEmpty())
+		Expect(requirements[1].ByComponents[0].Description).To(Equal("Justification in narrative form for AC-2 (1)"))
+	})
+
+	It("writes the parameters and all of the control originations", func() {
+		dir, err := ioutil.TempDir("", "oscal")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		component := opencontrols.NewComponent("My System", "my_system")
+		satisfies := opencontrols.NewSatisfies("AU-2")
+		satisfies.Parameters = []opencontrols.Section{{Key: "a", Text: "successful logins"}}
+		satisfies.SetControlOrigins([]string{"service_provider_corporate", "customer_configured"})
+		component.Satisfies = append(component.Satisfies, satisfies)
+		certification := opencontrols.NewCertification("FedRAMP", []string{"AU-2"})
+		Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+		// compliance-masonry needs a standards directory, even if it's empty.
+		Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+		data, errors := opencontrols.LoadFrom(dir)
+		Expect(errors).To(BeEmpty())
+
+		requirement := writeSSP(data).SystemSecurityPlan.ControlImplementation.ImplementedRequirements[0]
+
+		Expect(requirement.SetParameters).To(HaveLen(1))
+		Expect(requirement.SetParameters[0].ParamID).To(Equal("au-2_prm_a"))
+		Expect(requirement.SetParameters[0].Values).To(Equal([]string{"successful logins"}))
+		Expect(requirement.Props).To(HaveLen(2))
+		Expect(requirement.Props[0].Value).To(Equal("sp-corporate"))
+		Expect(requirement.Props[1].Value).To(Equal("customer-configured"))
+		Expect(requirement.ResponsibleRoles).To(BeEmpty())
+	})
+
+	It("writes the sections that the SSP requires", func() {
+		sections := writeSSPSections(fixtures.LoadOpenControlFixture(), opencontrols.System{})
+
+		Expect(sections["metadata"]).To(HaveKey("title"))
+		Expect(sections["import-profile"]).To(HaveKeyWithValue("href", "profile.json"))
+		characteristics := sections["system-characteristics"]
+		for _, key := range []string{"system-ids", "system-name", "description", "security-sensitivity-level",
+			"system-information", "security-impact-level", "status", "authorization-boundary"} {
+			Expect(characteristics).To(HaveKey(key))
+		}
+		Expect(characteristics["security-sensitivity-level"]).To(Equal("fips-199-moderate"))
+		informationTypes := characteristics["system-information"].(map[string]interface{})["information-types"]
+		Expect(informationTypes).To(HaveLen(1))
+		users := sections["system-implementation"]["users"]
+		Expect(users).To(HaveLen(1))
+		Expect(users.([]interface{})[0]).To(HaveKeyWithValue("role-ids", []interface{}{"aws-staff"}))
+		Expect(sections["system-implementation"]["components"]).To(HaveLen(1))
+		Expect(sections["control-implementation"]).To(HaveKey("implemented-requirements"))
+	})
+
+	It("fills in the system characteristics from the metadata of the system", func() {
+		system, err := opencontrols.LoadSystem(fixtures.FixturePath("system.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		system.Categorization = "High"
+		system.ImportProfile = "https://example.com/FedRAMP_HIGH-baseline_profile.json"
+
+		sections := writeSSPSections(fixtures.LoadOpenControlFixture(), system)
+
+		Expect(sections["metadata"]).To(HaveKeyWithValue("title", "Amazon Web Services System Security Plan"))
+		Expect(sections["metadata"]).To(HaveKeyWithValue("version", "1.2"))
+		Expect(sections["import-profile"]).To(HaveKeyWithValue("href", system.ImportProfile))
+		characteristics := sections["system-characteristics"]
+		Expect(characteristics).To(HaveKeyWithValue("system-name", "Amazon Web Services"))
+		Expect(characteristics).To(HaveKeyWithValue("system-name-short", "AWS"))
+		Expect(characteristics["system-ids"]).To(Equal([]interface{}{map[string]interface{}{
+			"identifier-type": "https://fedramp.gov",
+			"id":              "F1234567890",
+		}}))
+		Expect(characteristics["security-impact-level"]).To(HaveKeyWithValue("security-objective-integrity", "fips-199-high"))
+	})
+
+	It("writes a user without any responsible roles", func() {
+		dir, err := ioutil.TempDir("", "oscal")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		component := opencontrols.NewComponent("My System", "my_system")
+		component.Satisfies = append(component.Satisfies, opencontrols.NewSatisfies("AU-2"))
+		certification := opencontrols.NewCertification("FedRAMP", []string{"AU-2"})
+		Expect(opencontrols.WriteWorkspace(dir, component, certification)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(dir, "standards"), 0755)).To(Succeed())
+		data, errors := opencontrols.LoadFrom(dir)
+		Expect(errors).To(BeEmpty())
+
+		users := writeSSPSections(data, opencontrols.System{})["system-implementation"]["users"]
+
+		Expect(users).To(HaveLen(1))
+		Expect(users.([]interface{})[0]).To(HaveKeyWithValue("title", "System User"))
+	})
+
+	It("gives the same UUIDs to the same data", func() {
+		first := writeSSP(fixtures.LoadOpenControlFixture())
+		second := writeSSP(fixtures.LoadOpenControlFixture())
+		Expect(second).To(Equal(first))
+	})
+})
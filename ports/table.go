@@ -0,0 +1,119 @@
+package ports
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jbowtie/gokogiri/xml"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/xml/helper"
+)
+
+// Title is the title of the Ports, Protocols and Services table, which is in its caption, e.g.
+// `Table 10-1. Ports, Protocols and Services`. It leaves out the rest of the title, which differs between the versions
+// of the template.
+const Title = "Ports, Protocols"
+
+// columns are the prefixes of the (normalized) column headers, with the value of each entry for the column.
+var columns = []struct {
+	prefix string
+	value  func(opencontrols.PortProtocolService) string
+}{
+	{"port", func(e opencontrols.PortProtocolService) string { return e.Ports }},
+	{"protocol", func(e opencontrols.PortProtocolService) string { return e.Protocols }},
+	{"service", func(e opencontrols.PortProtocolService) string { return e.Services }},
+	{"purpose", func(e opencontrols.PortProtocolService) string { return e.Purpose }},
+	{"usedby", func(e opencontrols.PortProtocolService) string { return e.UsedBy }},
+}
+
+// Table represents the node in the Word docx XML tree that corresponds to the Ports, Protocols and Services table.
+type Table struct {
+	Root xml.Node
+}
+
+// NewTable creates a Table instance.
+func NewTable(root xml.Node) Table {
+	return Table{Root: root}
+}
+
+func normalizeHeader(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), ""))
+}
+
+// findHeader returns the index of the header row, i.e. the one with a `Protocols` column, and the value function of
+// each of its columns. The columns that aren't recognized are nil.
+func findHeader(rows []xml.Node) (int, []func(opencontrols.PortProtocolService) string, error) {
+	for idx, row := range rows {
+		cells, err := helper.SearchSubtree(row, `./w:tc`)
+		if err != nil {
+			return 0, nil, err
+		}
+		values := make([]func(opencontrols.PortProtocolService) string, len(cells))
+		found := false
+		for i, cell := range cells {
+			header := normalizeHeader(cell.Content())
+			for _, column := range columns {
+				if strings.HasPrefix(header, column.prefix) {
+					values[i] = column.value
+					found = found || column.prefix == "protocol"
+					break
+				}
+			}
+		}
+		if found {
+			return idx, values, nil
+		}
+	}
+	return 0, nil, errors.New("unable to find the header row of the Ports, Protocols and Services table")
+}
+
+// Fill replaces the rows after the header with a row for each of the entries, with the style of the first of them, so
+// that filling the table again (e.g. an SSP that was filled before) doesn't duplicate the rows. It returns the number
+// of rows that were added. The table is left as it is without any entries.
+func (t *Table) Fill(entries []opencontrols.PortProtocolService) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	rows, err := helper.SearchSubtree(t.Root, `./w:tr`)
+	if err != nil {
+		return 0, err
+	}
+	headerIdx, values, err := findHeader(rows)
+	if err != nil {
+		return 0, err
+	}
+	dataRows := rows[headerIdx+1:]
+	if len(dataRows) == 0 {
+		return 0, errors.New("the Ports, Protocols and Services table doesn't have a row to copy")
+	}
+
+	last := rows[len(rows)-1]
+	for _, entry := range entries {
+		row := dataRows[0].Duplicate(1)
+		err = last.AddNextSibling(row)
+		if err != nil {
+			return 0, err
+		}
+		last = row
+		cells, err := helper.SearchSubtree(row, `./w:tc`)
+		if err != nil {
+			return 0, err
+		}
+		for i, cell := range cells {
+			value := ""
+			if i < len(values) && values[i] != nil {
+				value = values[i](entry)
+			}
+			err = docxHelper.SetCellText(cell, value)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	for _, row := range dataRows {
+		row.Unlink()
+	}
+	return len(entries), nil
+}
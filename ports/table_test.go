@@ -0,0 +1,116 @@
+package ports_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/jbowtie/gokogiri/xml"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	. "github.com/opencontrol/fedramp-templater/ports"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func loadTable() Table {
+	content, err := ioutil.ReadFile(fixtures.FixturePath("ports_protocols_services.xml"))
+	Expect(err).NotTo(HaveOccurred())
+	doc, err := helper.ParseXML(content)
+	Expect(err).NotTo(HaveOccurred())
+	tables, err := doc.Search("//w:tbl")
+	Expect(err).NotTo(HaveOccurred())
+	return NewTable(tables[0])
+}
+
+func searchRows(table Table) []xml.Node {
+	rows, err := table.Root.Search("./w:tr")
+	Expect(err).NotTo(HaveOccurred())
+	return rows
+}
+
+// rowTexts returns the text of each row of the table, with the cells separated by `|`.
+func rowTexts(table Table) []string {
+	texts := []string{}
+	for _, row := range searchRows(table) {
+		cells, err := row.Search("./w:tc")
+		Expect(err).NotTo(HaveOccurred())
+		cellTexts := []string{}
+		for _, cell := range cells {
+			cellTexts = append(cellTexts, strings.TrimSpace(cell.Content()))
+		}
+		texts = append(texts, strings.Join(cellTexts, "|"))
+	}
+	return texts
+}
+
+var _ = Describe("Table", func() {
+	Describe("Fill", func() {
+		entries := []opencontrols.PortProtocolService{
+			{Ports: "443", Protocols: "TCP", Services: "HTTPS", Purpose: "Web console", UsedBy: "EC2"},
+			{Ports: "22", Protocols: "TCP", Services: "SSH", Purpose: "Administration", UsedBy: "Administrators"},
+		}
+
+		It("replaces the blank rows with a row for each entry", func() {
+			table := loadTable()
+
+			count, err := table.Fill(entries)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+			Expect(rowTexts(table)).To(Equal([]string{
+				"Ports (TCP/UDP)|Protocols|Services|Purpose|Used By",
+				"443|TCP|HTTPS|Web console|EC2",
+				"22|TCP|SSH|Administration|Administrators",
+			}))
+		})
+
+		It("keeps the style of the first data row", func() {
+			table := loadTable()
+
+			_, err := table.Fill(entries)
+
+			Expect(err).NotTo(HaveOccurred())
+			row := searchRows(table)[2].String()
+			Expect(row).To(ContainSubstring(`<w:tcW w:w="1200" w:type="dxa"/>`))
+			Expect(row).To(ContainSubstring(`<w:jc w:val="center"/>`))
+			Expect(row).To(ContainSubstring(`<w:rPr><w:sz w:val="18"/></w:rPr><w:t>TCP</w:t>`))
+		})
+
+		It("replaces the rows of the entries when the table is filled again", func() {
+			table := loadTable()
+			_, err := table.Fill(entries)
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := table.Fill(entries[1:])
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+			Expect(rowTexts(table)).To(Equal([]string{
+				"Ports (TCP/UDP)|Protocols|Services|Purpose|Used By",
+				"22|TCP|SSH|Administration|Administrators",
+			}))
+			Expect(searchRows(table)[1].String()).To(ContainSubstring(`<w:tcW w:w="1200" w:type="dxa"/>`))
+		})
+
+		It("leaves the table as it is without entries", func() {
+			table := loadTable()
+
+			count, err := table.Fill(nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+			Expect(searchRows(table)).To(HaveLen(3))
+		})
+
+		It("gives an error when the table doesn't have a header row", func() {
+			table := loadTable()
+			searchRows(table)[0].Unlink()
+
+			_, err := table.Fill(entries)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package reporter
+
+import (
+	"html/template"
+	"io"
+	"regexp"
+
+	"github.com/opencontrol/fedramp-templater/common/textdiff"
+)
+
+// htmlTemplate renders the diffs as a single self-contained page, so it can't reference any external assets.
+// Each control is a collapsible card, using `<details>` so that no scripts are needed.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>SSP diff report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #212121; }
+details { border: 1px solid #aeb0b5; border-radius: 4px; margin-bottom: 1em; }
+summary { background: #f1f1f1; cursor: pointer; font-weight: bold; padding: 0.5em 1em; }
+table { border-collapse: collapse; table-layout: fixed; width: 100%; }
+th, td { border-top: 1px solid #d6d7d9; padding: 0.5em 1em; text-align: left; vertical-align: top; }
+td.value { white-space: pre-wrap; }
+th.field { width: 15%; }
+.ssp { background: #fff1f0; }
+.yaml { background: #f0fff4; }
+del { background: #f9c0c0; text-decoration: none; }
+ins { background: #a9e5b5; text-decoration: none; }
+</style>
+</head>
+<body>
+<h1>SSP diff report</h1>
+<p>{{len .Diffs}} difference(s) found in {{len .Controls}} control(s).</p>
+{{range .Controls}}<details open>
+<summary>{{.Control}} ({{len .Diffs}} difference(s))</summary>
+<table>
+<tr><th class="field">Field</th><th>SSP</th><th>YAML</th></tr>
+{{range .Diffs}}<tr>
+<th class="field">{{.Field}}{{if .Key}} {{.Key}}{{end}}</th>
+<td class="value ssp">{{range .SSP}}{{if .Changed}}<del>{{.Text}}</del>{{else}}{{.Text}}{{end}}{{end}}</td>
+<td class="value yaml">{{range .YAML}}{{if .Changed}}<ins>{{.Text}}</ins>{{else}}{{.Text}}{{end}}{{end}}</td>
+</tr>
+{{end}}</table>
+</details>
+{{end}}</body>
+</html>
+`))
+
+// wordRegex splits text into words and the whitespace between them, so that the whitespace is kept when highlighting.
+var wordRegex = regexp.MustCompile(`\s+|\S+`)
+
+// htmlSegment is a piece of a value that is either the same in both sources or has changed.
+type htmlSegment struct {
+	Text    string
+	Changed bool
+}
+
+type htmlDiff struct {
+	Field string
+	Key   string
+	SSP   []htmlSegment
+	YAML  []htmlSegment
+}
+
+type htmlControl struct {
+	Control string
+	Diffs   []htmlDiff
+}
+
+type htmlReport struct {
+	Diffs    []Reporter
+	Controls []*htmlControl
+}
+
+// appendSegment adds the text to the segments, merging it with the previous segment if they are both (un)changed.
+func appendSegment(segments []htmlSegment, text string, changed bool) []htmlSegment {
+	if len(segments) > 0 && segments[len(segments)-1].Changed == changed {
+		segments[len(segments)-1].Text += text
+		return segments
+	}
+	return append(segments, htmlSegment{text, changed})
+}
+
+// highlightWords computes the word-level diff of the values and splits each one into its (un)changed segments.
+func highlightWords(sspValue, yamlValue string) (sspSegments, yamlSegments []htmlSegment) {
+	edits := textdiff.Diff(wordRegex.FindAllString(sspValue, -1), wordRegex.FindAllString(yamlValue, -1))
+	for _, edit := range edits {
+		switch edit.Op {
+		case textdiff.Equal:
+			sspSegments = appendSegment(sspSegments, edit.Text, false)
+			yamlSegments = appendSegment(yamlSegments, edit.Text, false)
+		case textdiff.Delete:
+			sspSegments = appendSegment(sspSegments, edit.Text, true)
+		case textdiff.Insert:
+			yamlSegments = appendSegment(yamlSegments, edit.Text, true)
+		}
+	}
+	return
+}
+
+// WriteHTMLTo writes the information for all of the reporters to the writer as an HTML page.
+// Each control is shown as a card with the SSP and YAML values side-by-side, with the changed words highlighted.
+func WriteHTMLTo(writer io.Writer, reporters []Reporter) error {
+	report := htmlReport{Diffs: reporters}
+	controls := map[string]*htmlControl{}
+	for _, reporter := range reporters {
+		diff := reporter.Diff()
+		control, exists := controls[diff.Control]
+		if !exists {
+			// keep the controls in the order that they were found in the SSP.
+			control = &htmlControl{Control: diff.Control}
+			controls[diff.Control] = control
+			report.Controls = append(report.Controls, control)
+		}
+		sspSegments, yamlSegments := highlightWords(diff.SSPValue, diff.YAMLValue)
+		control.Diffs = append(control.Diffs, htmlDiff{
+			Field: diff.Field,
+			Key:   diff.Key,
+			SSP:   sspSegments,
+			YAML:  yamlSegments,
+		})
+	}
+	return htmlTemplate.Execute(writer, report)
+}
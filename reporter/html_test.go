@@ -0,0 +1,43 @@
+package reporter_test
+
+import (
+	"bytes"
+
+	"github.com/opencontrol/fedramp-templater/reporter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTML", func() {
+	Describe("WriteHTMLTo", func() {
+		It("writes a card for each control", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteHTMLTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("<summary>AC-2 (1 difference(s))</summary>"))
+			Expect(buf.String()).To(ContainSubstring("<summary>AC-2 (1) (1 difference(s))</summary>"))
+		})
+
+		It("highlights the words that changed", func() {
+			reporters := []reporter.Reporter{
+				fakeReporter{reporter.Diff{Control: "AC-2", Field: "Narrative", Key: "a",
+					SSPValue: "Accounts are <reviewed> monthly", YAMLValue: "Accounts are <reviewed> annually",
+					Change: reporter.Modified}},
+			}
+			buf := &bytes.Buffer{}
+			err := reporter.WriteHTMLTo(buf, reporters)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring(`<td class="value ssp">Accounts are &lt;reviewed&gt; <del>monthly</del></td>`))
+			Expect(buf.String()).To(ContainSubstring(`<td class="value yaml">Accounts are &lt;reviewed&gt; <ins>annually</ins></td>`))
+		})
+
+		It("doesn't reference any external assets", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteHTMLTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).NotTo(ContainSubstring("http"))
+			Expect(buf.String()).NotTo(ContainSubstring("<script"))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	ClassName string         `xml:"classname,attr"`
+	Name      string         `xml:"name,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// WriteJUnitTo writes the information for all of the reporters to the writer as a JUnit XML report.
+// Each control is a test case, with a failure for each of its diffs. The controls of the SSP that don't have any diffs
+// are passing test cases.
+func WriteJUnitTo(writer io.Writer, controls []string, reporters []Reporter) error {
+	suite := junitTestSuite{Name: "SSP diff"}
+	testCases := map[string]*junitTestCase{}
+	// keep the controls in the order that they were found in the SSP.
+	addTestCase := func(control string) *junitTestCase {
+		testCase, exists := testCases[control]
+		if !exists {
+			testCase = &junitTestCase{ClassName: Diff{Control: control}.Family(), Name: control}
+			testCases[control] = testCase
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		return testCase
+	}
+	for _, control := range controls {
+		addTestCase(control)
+	}
+	for _, reporter := range reporters {
+		diff := reporter.Diff()
+		testCase := addTestCase(diff.Control)
+		text := &bytes.Buffer{}
+		err := reporter.WriteTextTo(text)
+		if err != nil {
+			return err
+		}
+		testCase.Failures = append(testCase.Failures, junitFailure{
+			Message: fmt.Sprintf("%s differs between the SSP and the YAML", diff.Field),
+			Type:    string(diff.Change),
+			Text:    text.String(),
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+	for _, testCase := range suite.TestCases {
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+	}
+
+	suites := junitTestSuites{
+		Name:       "fedramp-templater",
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		TestSuites: []junitTestSuite{suite},
+	}
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
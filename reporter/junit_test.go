@@ -0,0 +1,78 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+
+	"github.com/opencontrol/fedramp-templater/reporter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JUnit", func() {
+	Describe("WriteJUnitTo", func() {
+		It("writes a test case per control with a failure per diff", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteJUnitTo(buf, []string{"AC-2", "AC-2 (1)"}, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites struct {
+				Tests    int `xml:"tests,attr"`
+				Failures int `xml:"failures,attr"`
+				Cases    []struct {
+					Name     string `xml:"name,attr"`
+					Failures []struct {
+						Type string `xml:"type,attr"`
+					} `xml:"failure"`
+				} `xml:"testsuite>testcase"`
+			}
+			err = xml.Unmarshal(buf.Bytes(), &suites)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites.Tests).To(Equal(2))
+			Expect(suites.Failures).To(Equal(2))
+			Expect(suites.Cases[1].Name).To(Equal("AC-2 (1)"))
+			Expect(suites.Cases[1].Failures[0].Type).To(Equal("yaml_only"))
+		})
+
+		It("counts the test cases that fail rather than the diffs", func() {
+			reporters := append(fakeReporters(), fakeReporter{reporter.Diff{Control: "AC-2", Field: "Parameter",
+				Key: "AC-2(a)", SSPValue: "60 days", YAMLValue: "90 days", Change: reporter.Modified}})
+			buf := &bytes.Buffer{}
+			err := reporter.WriteJUnitTo(buf, []string{"AC-2", "AC-2 (1)"}, reporters)
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites struct {
+				Tests    int `xml:"tests,attr"`
+				Failures int `xml:"failures,attr"`
+			}
+			err = xml.Unmarshal(buf.Bytes(), &suites)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites.Tests).To(Equal(2))
+			Expect(suites.Failures).To(Equal(2))
+		})
+
+		It("writes a passing test case for each control without any diffs", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteJUnitTo(buf, []string{"AC-2", "AC-2 (1)", "AC-6"}, []reporter.Reporter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites struct {
+				Tests    int `xml:"tests,attr"`
+				Failures int `xml:"failures,attr"`
+				Cases    []struct {
+					ClassName string     `xml:"classname,attr"`
+					Name      string     `xml:"name,attr"`
+					Failures  []struct{} `xml:"failure"`
+				} `xml:"testsuite>testcase"`
+			}
+			err = xml.Unmarshal(buf.Bytes(), &suites)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites.Tests).To(Equal(3))
+			Expect(suites.Failures).To(Equal(0))
+			Expect(suites.Cases[2].ClassName).To(Equal("AC"))
+			Expect(suites.Cases[2].Name).To(Equal("AC-6"))
+			Expect(suites.Cases[2].Failures).To(BeEmpty())
+		})
+	})
+})
@@ -1,10 +1,101 @@
 package reporter
 
-import "io"
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/source"
+)
 
 // Reporter is construct that reports information in various formats.
 //
 // WriteTextTo will take the information it has and writes it as plain text to the writer.
+//
+// WriteJSONTo will take the information it has and writes it as a single line of JSON to the writer.
+//
+// Diff returns the structured information that the reporter writes.
 type Reporter interface {
 	WriteTextTo(io.Writer) error
+	WriteJSONTo(io.Writer) error
+	Diff() Diff
+}
+
+// ChangeType describes how a field differs between the SSP and the YAML.
+type ChangeType string
+
+const (
+	// Modified indicates that the field has a value in both the SSP and the YAML, but they are not the same.
+	Modified ChangeType = "modified"
+	// OnlyInSSP indicates that the field only has a value in the SSP.
+	OnlyInSSP ChangeType = "ssp_only"
+	// OnlyInYAML indicates that the field only has a value in the YAML.
+	OnlyInYAML ChangeType = "yaml_only"
+	// Conflict indicates that the field was changed in both the SSP and the YAML since the base SSP, to different values.
+	Conflict ChangeType = "conflict"
+)
+
+// Diff is the machine-readable representation of a difference for a field of a control.
+type Diff struct {
+	// Control is the control ID, e.g. `AC-2 (1)`.
+	Control string `json:"control"`
+	// Field is the kind of field, e.g. `Responsible Role` or `Parameter`.
+	Field string `json:"field"`
+	// Key is the part or parameter key within the field, if the field has multiple parts.
+	Key       string `json:"key,omitempty"`
+	SSPValue  string `json:"ssp_value"`
+	YAMLValue string `json:"yaml_value"`
+	// BaseValue is the value of the field in the base SSP, for conflicts.
+	BaseValue string     `json:"base_value,omitempty"`
+	Change    ChangeType `json:"change"`
+}
+
+// Family returns the control family of the diff, e.g. `AC` for `AC-2 (1)`.
+func (d Diff) Family() string {
+	return strings.TrimSpace(strings.SplitN(d.Control, "-", 2)[0])
+}
+
+// Source returns the source(s) that have a value for the field.
+func (d Diff) Source() string {
+	switch d.Change {
+	case OnlyInSSP:
+		return string(source.SSP)
+	case OnlyInYAML:
+		return string(source.YAML)
+	default:
+		return string(source.SSP) + ", " + string(source.YAML)
+	}
+}
+
+// WriteTextTo writes the information for each of the reporters as plain text to the writer.
+func WriteTextTo(writer io.Writer, reporters []Reporter) error {
+	for _, reporter := range reporters {
+		err := reporter.WriteTextTo(writer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSONTo writes the information for all of the reporters as a single JSON array to the writer.
+func WriteJSONTo(writer io.Writer, reporters []Reporter) error {
+	diffs := []Diff{}
+	for _, reporter := range reporters {
+		diffs = append(diffs, reporter.Diff())
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diffs)
+}
+
+// WriteNDJSONTo writes the information for each of the reporters as newline-delimited JSON to the writer.
+func WriteNDJSONTo(writer io.Writer, reporters []Reporter) error {
+	for _, reporter := range reporters {
+		err := reporter.WriteJSONTo(writer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,81 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opencontrol/fedramp-templater/reporter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeReporter is a Reporter that reports a fixed diff.
+type fakeReporter struct {
+	diff reporter.Diff
+}
+
+func (r fakeReporter) WriteTextTo(writer io.Writer) error {
+	_, err := fmt.Fprintf(writer, "%s: %s\n", r.diff.Control, r.diff.Field)
+	return err
+}
+
+func (r fakeReporter) WriteJSONTo(writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(r.diff)
+}
+
+func (r fakeReporter) Diff() reporter.Diff {
+	return r.diff
+}
+
+func fakeReporters() []reporter.Reporter {
+	return []reporter.Reporter{
+		fakeReporter{reporter.Diff{Control: "AC-2", Field: "Responsible Role", SSPValue: "Admin", YAMLValue: "AWS Staff", Change: reporter.Modified}},
+		fakeReporter{reporter.Diff{Control: "AC-2 (1)", Field: "Control Origination", YAMLValue: "shared", Change: reporter.OnlyInYAML}},
+	}
+}
+
+var _ = Describe("Reporter", func() {
+	Describe("WriteTextTo", func() {
+		It("writes each of the reporters as text", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteTextTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("AC-2: Responsible Role\nAC-2 (1): Control Origination\n"))
+		})
+	})
+
+	Describe("WriteJSONTo", func() {
+		It("writes all of the reporters as a JSON array", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteJSONTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+
+			var diffs []reporter.Diff
+			err = json.Unmarshal(buf.Bytes(), &diffs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(HaveLen(2))
+			Expect(diffs[1].Change).To(Equal(reporter.OnlyInYAML))
+		})
+
+		It("writes an empty array when there are no reporters", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteJSONTo(buf, []reporter.Reporter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("[]\n"))
+		})
+	})
+
+	Describe("WriteNDJSONTo", func() {
+		It("writes each of the reporters on its own line", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteNDJSONTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(
+				`{"control":"AC-2","field":"Responsible Role","ssp_value":"Admin","yaml_value":"AWS Staff","change":"modified"}` + "\n" +
+					`{"control":"AC-2 (1)","field":"Control Origination","ssp_value":"","yaml_value":"shared","change":"yaml_only"}` + "\n"))
+		})
+	})
+})
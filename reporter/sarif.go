@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "fedramp-templater"
+	toolURI      = "https://github.com/opencontrol/fedramp-templater"
+	// sarifSourceRoot is the base of the relative artifact URIs, which is the directory the tool was run in.
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+	Index     *int   `json:"index,omitempty"`
+}
+
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifRun struct {
+	Tool      sarifTool       `json:"tool"`
+	Artifacts []sarifArtifact `json:"artifacts"`
+	Results   []sarifResult   `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRuleID converts the field to the ID of the rule, e.g. `Responsible Role` to `responsible-role`.
+func sarifRuleID(field string) string {
+	return strings.ToLower(strings.Join(strings.Fields(field), "-"))
+}
+
+// newSARIFArtifactLocation returns the location of the artifact at the path. Absolute paths are converted to `file`
+// URIs, and relative ones are relative to the directory the tool was run in.
+func newSARIFArtifactLocation(path string) sarifArtifactLocation {
+	uri := url.URL{Path: filepath.ToSlash(path)}
+	if !filepath.IsAbs(path) {
+		return sarifArtifactLocation{URI: uri.String(), URIBaseID: sarifSourceRoot}
+	}
+	uri.Scheme = "file"
+	// Windows paths start with the drive, e.g. `C:/`.
+	if !strings.HasPrefix(uri.Path, "/") {
+		uri.Path = "/" + uri.Path
+	}
+	return sarifArtifactLocation{URI: uri.String()}
+}
+
+// WriteSARIFTo writes the information for all of the reporters to the writer as a SARIF log.
+// The SSP at `artifactPath` is the artifact of each result, and the control is the logical location.
+func WriteSARIFTo(writer io.Writer, reporters []Reporter, artifactPath string) error {
+	artifactIndex := 0
+	artifactLocation := newSARIFArtifactLocation(artifactPath)
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolURI,
+			Rules:          []sarifRule{},
+		}},
+		Artifacts: []sarifArtifact{{Location: artifactLocation}},
+		Results:   []sarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	for _, reporter := range reporters {
+		diff := reporter.Diff()
+		ruleID := sarifRuleID(diff.Field)
+		ruleIndex, exists := ruleIndexes[ruleID]
+		if !exists {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndexes[ruleID] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifMessage{fmt.Sprintf("%s differs between the SSP and the YAML", diff.Field)},
+			})
+		}
+
+		text := &bytes.Buffer{}
+		err := reporter.WriteTextTo(text)
+		if err != nil {
+			return err
+		}
+		fullyQualifiedName := diff.Control + "/" + diff.Field
+		if diff.Key != "" {
+			fullyQualifiedName += "/" + diff.Key
+		}
+		resultLocation := artifactLocation
+		resultLocation.Index = &artifactIndex
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: ruleIndex,
+			Level:     "error",
+			Message:   sarifMessage{strings.TrimSpace(text.String())},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: resultLocation,
+				},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               diff.Control,
+					FullyQualifiedName: fullyQualifiedName,
+				}},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,86 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/opencontrol/fedramp-templater/reporter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// sarifArtifactLocation writes the SARIF log for the SSP at the path and returns the location of its artifact.
+func sarifArtifactLocation(path string) map[string]interface{} {
+	buf := &bytes.Buffer{}
+	err := reporter.WriteSARIFTo(buf, fakeReporters(), path)
+	Expect(err).NotTo(HaveOccurred())
+
+	var log struct {
+		Runs []struct {
+			Artifacts []struct {
+				Location map[string]interface{} `json:"location"`
+			} `json:"artifacts"`
+		} `json:"runs"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &log)
+	Expect(err).NotTo(HaveOccurred())
+	return log.Runs[0].Artifacts[0].Location
+}
+
+var _ = Describe("SARIF", func() {
+	Describe("WriteSARIFTo", func() {
+		It("writes a result per diff with the SSP as the artifact and the control as the logical location", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteSARIFTo(buf, fakeReporters(), "ssp/FedRAMP.docx")
+			Expect(err).NotTo(HaveOccurred())
+
+			var log struct {
+				Version string `json:"version"`
+				Runs    []struct {
+					Tool struct {
+						Driver struct {
+							Rules []struct {
+								ID string `json:"id"`
+							} `json:"rules"`
+						} `json:"driver"`
+					} `json:"tool"`
+					Results []struct {
+						RuleID    string `json:"ruleId"`
+						Locations []struct {
+							PhysicalLocation struct {
+								ArtifactLocation struct {
+									URI string `json:"uri"`
+								} `json:"artifactLocation"`
+							} `json:"physicalLocation"`
+							LogicalLocations []struct {
+								Name string `json:"name"`
+							} `json:"logicalLocations"`
+						} `json:"locations"`
+					} `json:"results"`
+				} `json:"runs"`
+			}
+			err = json.Unmarshal(buf.Bytes(), &log)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(log.Version).To(Equal("2.1.0"))
+			Expect(log.Runs[0].Tool.Driver.Rules).To(HaveLen(2))
+			result := log.Runs[0].Results[0]
+			Expect(result.RuleID).To(Equal("responsible-role"))
+			Expect(result.Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("ssp/FedRAMP.docx"))
+			Expect(result.Locations[0].LogicalLocations[0].Name).To(Equal("AC-2"))
+		})
+
+		It("makes relative paths relative to the source root", func() {
+			Expect(sarifArtifactLocation("ssp/FedRAMP SSP.docx")).To(Equal(map[string]interface{}{
+				"uri":       "ssp/FedRAMP%20SSP.docx",
+				"uriBaseId": "%SRCROOT%",
+			}))
+		})
+
+		It("converts absolute paths to file URIs", func() {
+			Expect(sarifArtifactLocation("/home/user/FedRAMP SSP.docx")).To(Equal(map[string]interface{}{
+				"uri": "file:///home/user/FedRAMP%20SSP.docx",
+			}))
+		})
+	})
+})
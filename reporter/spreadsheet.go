@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+
+	"github.com/opencontrol/fedramp-templater/xlsx"
+)
+
+// spreadsheetHeader is the header row for the spreadsheet formats of the diffs.
+var spreadsheetHeader = []string{"Control", "Field", "Key", "SSP Value", "YAML Value", "Change", "Source"}
+
+// spreadsheetRow returns the cells of the diff for the spreadsheet formats, in the same order as the header.
+func spreadsheetRow(diff Diff) []string {
+	return []string{diff.Control, diff.Field, diff.Key, diff.SSPValue, diff.YAMLValue, string(diff.Change), diff.Source()}
+}
+
+// WriteCSVTo writes the information for all of the reporters as CSV to the writer, one row per diff.
+func WriteCSVTo(writer io.Writer, reporters []Reporter) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(spreadsheetHeader)
+	if err != nil {
+		return err
+	}
+	for _, reporter := range reporters {
+		err = csvWriter.Write(spreadsheetRow(reporter.Diff()))
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// familySummary holds the counts for a single control family.
+type familySummary struct {
+	controls map[string]bool
+	diffs    int
+}
+
+// WriteXLSXTo writes the information for all of the reporters as an Excel workbook to the writer.
+// The workbook contains one row per diff, as well as a summary sheet with the counts for each control family.
+func WriteXLSXTo(writer io.Writer, reporters []Reporter) error {
+	workbook := xlsx.NewWorkbook()
+	diffSheet := workbook.AddSheet("Discrepancies")
+	diffSheet.AddHeader(spreadsheetHeader...)
+
+	summaries := map[string]*familySummary{}
+	for _, reporter := range reporters {
+		diff := reporter.Diff()
+		row := []interface{}{}
+		for _, cell := range spreadsheetRow(diff) {
+			row = append(row, cell)
+		}
+		diffSheet.AddRow(row...)
+
+		summary, exists := summaries[diff.Family()]
+		if !exists {
+			summary = &familySummary{controls: map[string]bool{}}
+			summaries[diff.Family()] = summary
+		}
+		summary.controls[diff.Control] = true
+		summary.diffs++
+	}
+
+	families := []string{}
+	for family := range summaries {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+
+	summarySheet := workbook.AddSheet("Summary")
+	summarySheet.AddHeader("Family", "Controls", "Discrepancies")
+	totalControls, totalDiffs := 0, 0
+	for _, family := range families {
+		summary := summaries[family]
+		summarySheet.AddRow(family, len(summary.controls), summary.diffs)
+		totalControls += len(summary.controls)
+		totalDiffs += summary.diffs
+	}
+	summarySheet.AddRow("Total", totalControls, totalDiffs)
+
+	return workbook.Write(writer)
+}
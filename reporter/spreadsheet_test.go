@@ -0,0 +1,37 @@
+package reporter_test
+
+import (
+	"bytes"
+
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/reporter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spreadsheet", func() {
+	Describe("WriteCSVTo", func() {
+		It("writes a header and one row per diff", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteCSVTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("Control,Field,Key,SSP Value,YAML Value,Change,Source\n" +
+				"AC-2,Responsible Role,,Admin,AWS Staff,modified,\"SSP, YAML\"\n" +
+				"AC-2 (1),Control Origination,,,shared,yaml_only,YAML\n"))
+		})
+	})
+
+	Describe("WriteXLSXTo", func() {
+		It("writes the diffs and the per-family summary", func() {
+			buf := &bytes.Buffer{}
+			err := reporter.WriteXLSXTo(buf, fakeReporters())
+			Expect(err).NotTo(HaveOccurred())
+
+			summaryXML := fixtures.ReadParts(buf.Bytes())["xl/worksheets/sheet2.xml"]
+			By("counting both controls and their diffs in the AC family")
+			Expect(summaryXML).To(ContainSubstring(`<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">AC</t></is></c>` +
+				`<c r="B2"><v>2</v></c><c r="C2"><v>2</v></c></row>`))
+		})
+	})
+})
@@ -3,53 +3,126 @@ package ssp
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
 
 	"github.com/jbowtie/gokogiri/xml"
-	"github.com/opencontrol/doc-template/docx"
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	"github.com/opencontrol/fedramp-templater/docx"
 	"github.com/opencontrol/fedramp-templater/docx/helper"
+	xmlHelper "github.com/opencontrol/fedramp-templater/xml/helper"
 )
 
-// SummaryTablesXPath is the pattern used to find summary tables within an SSP's XML.
+// SummaryTablesXPath is the pattern used to find summary tables within an SSP's XML of the v2.1 template. Use the
+// SummaryTablesXPath of the Profile of the document instead, which also supports the other versions.
 const SummaryTablesXPath = "//w:tbl[contains(normalize-space(.), 'Control Summary') or contains(normalize-space(.), 'Control Enhancement Summary')]"
 
 // Document represents a system security plan file and its contents.
 type Document struct {
-	wordDoc *docx.Docx
-	xmlDoc  *xml.XmlDocument
-}
-
-func getWordDoc(path string) (doc *docx.Docx, err error) {
-	doc = new(docx.Docx)
-	err = doc.ReadFile(path)
-	return
+	pkg      *docx.Package
+	xmlDoc   *xml.XmlDocument
+	comments *docx.Comments
+	profile  profile.Profile
+	detected bool
+	// ids hands out the IDs of the comments and revision marks that are added to the document.
+	ids *helper.IDs
 }
 
 // Load creates a new Document from the provided file path.
 func Load(path string) (ssp *Document, err error) {
-	wordDoc, err := getWordDoc(path)
+	pkg, err := docx.OpenPackage(path)
 	if err != nil {
 		return
 	}
-	xmlDoc, err := helper.GenerateXML(wordDoc)
+	ssp, err = newDocument(pkg)
 	if err != nil {
 		return
 	}
-
-	ssp = &Document{wordDoc, xmlDoc}
+	log.Printf("Read File `%s`", path)
 	return
 }
 
+// LoadFrom creates a new Document from the provided reader, which has the provided size in bytes, e.g. a document that
+// is held in memory.
+func LoadFrom(r io.ReaderAt, size int64) (*Document, error) {
+	pkg, err := docx.ReadPackage(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(pkg)
+}
+
+func newDocument(pkg *docx.Package) (*Document, error) {
+	content, _ := pkg.Part(docx.DocumentPart)
+	xmlDoc, err := helper.ParseXML(content)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := tableLabels(xmlDoc)
+	if err != nil {
+		xmlDoc.Free()
+		return nil, err
+	}
+	ids := &helper.IDs{}
+	err = ids.Skip(xmlDoc)
+	if err != nil {
+		xmlDoc.Free()
+		return nil, err
+	}
+	p, detected := profile.Detect(labels)
+	return &Document{pkg: pkg, xmlDoc: xmlDoc, profile: p, detected: detected, ids: ids}, nil
+}
+
+// tableLabels returns the labels of the tables, i.e. the first row of each table and the first cell of each of the
+// other rows (e.g. `Part a`), one per line, which tell the versions of the template apart.
+func tableLabels(xmlDoc *xml.XmlDocument) (string, error) {
+	tables, err := xmlDoc.Search("//w:tbl")
+	if err != nil {
+		return "", err
+	}
+	labels := []string{}
+	for _, table := range tables {
+		cells, err := table.Search("./w:tr[1] | ./w:tr[position() > 1]/w:tc[1]")
+		if err != nil {
+			return "", err
+		}
+		for _, cell := range cells {
+			labels = append(labels, strings.TrimSpace(helper.Text(cell)))
+		}
+	}
+	return strings.Join(labels, "\n"), nil
+}
+
+// Profile returns the profile of the version of the template that the document is from, which is detected when it is
+// loaded.
+func (s *Document) Profile() profile.Profile {
+	return s.profile
+}
+
+// ProfileDetected returns true if the version of the template of the document was detected or set.
+func (s *Document) ProfileDetected() bool {
+	return s.detected
+}
+
+// SetProfile overrides the detected profile, e.g. if the document is from a version of the template that can't be
+// detected.
+func (s *Document) SetProfile(p profile.Profile) {
+	s.profile = p
+	s.detected = true
+}
+
 // SummaryTables returns the summary tables for the controls and the control enhancements.
 func (s *Document) SummaryTables() ([]xml.Node, error) {
 	// find the tables matching the provided headers, ignoring whitespace
-	return s.xmlDoc.Search(SummaryTablesXPath)
+	return s.xmlDoc.Search(s.profile.SummaryTablesXPath)
 }
 
 // to retrieve all narrative tables, pass in an empty string
 func (s *Document) findNarrativeTables(control string) ([]xml.Node, error) {
 	// find the tables matching the provided headers, ignoring whitespace
-	xpath := fmt.Sprintf("//w:tbl[contains(normalize-space(.), '%s What is the solution and how is it implemented?')]", control)
-	return s.xmlDoc.Search(xpath)
+	return s.xmlDoc.Search(s.profile.NarrativeTablesXPathFor(control))
 }
 
 // NarrativeTables returns the narrative tables for all controls and the control enhancements.
@@ -74,24 +147,139 @@ func (s *Document) NarrativeTable(control string) (table xml.Node, err error) {
 	return
 }
 
+// TitledTables returns the tables with the title (e.g. `Information System Owner`) in their first row or in the
+// caption before them.
+func (s *Document) TitledTables(title string) ([]xml.Node, error) {
+	literal := xmlHelper.Literal(title)
+	xpath := fmt.Sprintf("//w:tbl[contains(normalize-space(w:tr[1]), %s) or "+
+		"contains(normalize-space(preceding-sibling::w:p[1]), %s)]", literal, literal)
+	return s.xmlDoc.Search(xpath)
+}
+
+// AddComment attaches a Word comment with the provided text to the node (e.g. a table cell).
+func (s *Document) AddComment(node xml.Node, author, text string) (err error) {
+	if s.comments == nil {
+		s.comments, err = docx.LoadComments(s.pkg, s.ids)
+		if err != nil {
+			return
+		}
+	}
+	return s.comments.Add(node, author, text)
+}
+
+// TrackChanges makes the revision take the IDs of its revision marks from the document, so that they don't collide
+// with the IDs of the existing revision marks, comments, etc.
+func (s *Document) TrackChanges(revision *helper.Revision) {
+	revision.UseIDs(s.ids)
+}
+
+// SetCoreProperty sets the core property with the provided qualified name (e.g. `dc:title`) of the Word document.
+func (s *Document) SetCoreProperty(name, value string) error {
+	return s.pkg.SetCoreProperty(name, value)
+}
+
+// SetCustomProperty sets the custom property with the provided name of the Word document.
+func (s *Document) SetCustomProperty(name, value string) error {
+	return s.pkg.SetCustomProperty(name, value)
+}
+
+// ReplaceHeaderFooterPlaceholders replaces the placeholders (e.g. `<Information System Name>`) in the headers and
+// footers of the Word document with their values, and returns the number of replacements.
+func (s *Document) ReplaceHeaderFooterPlaceholders(placeholders map[string]string) (int, error) {
+	count := 0
+	names := append(s.pkg.PartNames("word/header*.xml"), s.pkg.PartNames("word/footer*.xml")...)
+	for _, name := range names {
+		content, _ := s.pkg.Part(name)
+		xmlDoc, err := helper.ParseXML(content)
+		if err != nil {
+			return count, err
+		}
+		replaced, err := replacePlaceholders(xmlDoc, placeholders)
+		if err == nil && replaced > 0 {
+			s.pkg.SetPart(name, []byte(xmlDoc.String()))
+		}
+		xmlDoc.Free()
+		if err != nil {
+			return count, err
+		}
+		count += replaced
+	}
+	return count, nil
+}
+
+func replacePlaceholders(xmlDoc *xml.XmlDocument, placeholders map[string]string) (int, error) {
+	count := 0
+	paragraphs, err := xmlDoc.Search("//w:p")
+	if err != nil {
+		return count, err
+	}
+	for _, paragraph := range paragraphs {
+		for placeholder, value := range placeholders {
+			replaced, err := helper.ReplacePlaceholder(paragraph, placeholder, value)
+			if err != nil {
+				return count, err
+			}
+			count += replaced
+		}
+	}
+	return count, nil
+}
+
 // Content retrieves the text from within the Word document.
 func (s *Document) Content() string {
-	return s.wordDoc.GetContent()
+	content, _ := s.pkg.Part(docx.DocumentPart)
+	return string(content)
 }
 
 // UpdateContent modifies the state of the underlying Word document. Note this is purely for bookkeeping in memory, and does not actually make any changes to the file.
-func (s *Document) UpdateContent() {
-	content := s.xmlDoc.String()
-	s.wordDoc.UpdateContent(content)
+func (s *Document) UpdateContent() error {
+	s.pkg.SetPart(docx.DocumentPart, []byte(s.xmlDoc.String()))
+	if s.comments != nil {
+		return s.comments.Save(s.pkg)
+	}
+	return nil
 }
 
 // CopyTo copies the contents of this Word document to a new file at the provided path.
 func (s *Document) CopyTo(path string) error {
-	return s.wordDoc.WriteToFile(path, s.Content())
+	target, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	_, err = s.WriteTo(target)
+	if err != nil {
+		return err
+	}
+	log.Printf("Exporting data to %s", path)
+	return nil
+}
+
+// countingWriter counts the bytes that are written to the underlying writer.
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	return n, err
+}
+
+// WriteTo writes the contents of this Word document to the writer, e.g. to keep it in memory, and returns the number
+// of bytes written. Call UpdateContent first to include the changes to the document.
+func (s *Document) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{writer: w}
+	err := s.pkg.Write(counter)
+	return counter.count, err
 }
 
 // Close releases the underlying resources.
 func (s *Document) Close() error {
+	if s.comments != nil {
+		s.comments.Free()
+	}
 	s.xmlDoc.Free()
-	return s.wordDoc.Close()
+	return nil
 }
@@ -1,6 +1,15 @@
 package ssp_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontrol/fedramp-templater/common/profile"
+	"github.com/opencontrol/fedramp-templater/docx"
+	"github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/fixtures"
 	. "github.com/opencontrol/fedramp-templater/ssp"
 
@@ -17,12 +26,91 @@ var _ = Describe("SSP", func() {
 			Expect(doc.Content()).To(ContainSubstring("Control Enhancement"))
 		})
 
+		It("detects the version of the template", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+			defer doc.Close()
+
+			Expect(doc.Profile().Version).To(Equal(profile.V2_1))
+			Expect(doc.ProfileDetected()).To(BeTrue())
+		})
+
+		It("detects the Rev 5 template from the table headers", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_rev5.docx")
+			defer doc.Close()
+
+			Expect(doc.Profile().Version).To(Equal(profile.Rev5))
+			tables, err := doc.NarrativeTables()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(tables)).To(Equal(1))
+		})
+
+		It("detects the Rev 5 template from the parts of a doc without any control enhancements", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2_rev5.docx")
+			defer doc.Close()
+
+			Expect(doc.ProfileDetected()).To(BeTrue())
+			Expect(doc.Profile().Version).To(Equal(profile.Rev5))
+			tables, err := doc.NarrativeTables()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(tables)).To(Equal(1))
+			tables, err = doc.SummaryTables()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(tables)).To(Equal(1))
+		})
+
+		It("doesn't detect the Rev 5 template from the prose of a v2.1 doc", func() {
+			content, err := ioutil.ReadFile(fixtures.FixturePath("FedRAMP_ac-2-1_v2.1.docx"))
+			Expect(err).NotTo(HaveOccurred())
+			pkg, err := docx.ReadPackage(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+			body, _ := pkg.Part(docx.DocumentPart)
+			prose := `<w:p><w:r><w:t>See Part a. of AC-2(1) Control Enhancement Summary Information.</w:t></w:r></w:p>`
+			pkg.SetPart(docx.DocumentPart, bytes.Replace(body, []byte("<w:body>"), []byte("<w:body>"+prose), 1))
+			buf := &bytes.Buffer{}
+			Expect(pkg.Write(buf)).To(Succeed())
+
+			doc, err := LoadFrom(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			Expect(err).NotTo(HaveOccurred())
+			defer doc.Close()
+
+			Expect(doc.Content()).To(ContainSubstring("See Part a. of AC-2(1)"))
+			Expect(doc.Profile().Version).To(Equal(profile.V2_1))
+			Expect(doc.ProfileDetected()).To(BeTrue())
+		})
+
 		It("give an error when the doc isn't found", func() {
 			_, err := Load("non-existent.docx")
 			Expect(err).To(HaveOccurred())
 		})
 	})
 
+	Describe("LoadFrom", func() {
+		It("reads the doc from memory and writes it back", func() {
+			content, err := ioutil.ReadFile(fixtures.FixturePath("FedRAMP_ac-2-1_v2.1.docx"))
+			Expect(err).NotTo(HaveOccurred())
+
+			doc, err := LoadFrom(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+			defer doc.Close()
+			Expect(doc.Content()).To(ContainSubstring("Control Enhancement"))
+
+			buf := &bytes.Buffer{}
+			n, err := doc.WriteTo(buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(buf.Len())))
+			copied, err := LoadFrom(bytes.NewReader(buf.Bytes()), n)
+			Expect(err).NotTo(HaveOccurred())
+			defer copied.Close()
+			Expect(copied.Content()).To(Equal(doc.Content()))
+		})
+	})
+
+	Describe("SummaryTablesXPath", func() {
+		It("is the pattern of the v2.1 template", func() {
+			Expect(SummaryTablesXPath).To(Equal(profile.Default().SummaryTablesXPath))
+		})
+	})
+
 	Describe("SummaryTables", func() {
 		It("returns the tables", func() {
 			doc := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
@@ -46,4 +134,80 @@ var _ = Describe("SSP", func() {
 			Expect(len(tables)).To(Equal(8))
 		})
 	})
+
+	Describe("TitledTables", func() {
+		It("returns the tables with the title in their first row", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+
+			tables, err := doc.TitledTables("Control Enhancement Summary")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(tables)).To(Equal(1))
+		})
+
+		It("searches for the titles with quotes", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+
+			tables, err := doc.TitledTables(`System Owner's "Point of Contact"`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tables).To(BeEmpty())
+		})
+	})
+
+	Describe("TrackChanges", func() {
+		It("continues the IDs of the doc and shares them with the comments", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			table, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+			cells, err := table.Search(".//w:tc")
+			Expect(err).NotTo(HaveOccurred())
+			revision := helper.NewRevision("Reviewer", time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC))
+
+			doc.TrackChanges(revision)
+			Expect(revision.ReplaceCell(cells[len(cells)-1], "Inserted")).To(Succeed())
+			Expect(doc.AddComment(table, "Reviewer", "Comment")).To(Succeed())
+			Expect(doc.UpdateContent()).To(Succeed())
+
+			Expect(doc.Content()).To(ContainSubstring(`<w:ins w:id="15"`))
+			Expect(doc.Content()).NotTo(ContainSubstring(`<w:commentRangeStart w:id="15"/>`))
+			Expect(doc.Content()).To(MatchRegexp(`<w:commentRangeStart w:id="(1[6-9]|[2-9][0-9])"/>`))
+		})
+	})
+
+	Describe("AddComment", func() {
+		It("adds the comments part to the copy of the doc", func() {
+			doc := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer doc.Close()
+			table, err := doc.NarrativeTable("AC-2 (1)")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = doc.AddComment(table, "Reviewer", "First line\nSecond line")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.UpdateContent()).To(Succeed())
+			// the IDs up to 14 are used by the bookmarks of the doc.
+			Expect(doc.Content()).To(ContainSubstring(`<w:commentRangeStart w:id="15"/>`))
+			Expect(doc.Content()).To(ContainSubstring(`<w:commentReference w:id="15"/>`))
+
+			dir, err := ioutil.TempDir("", "ssp")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "annotated.docx")
+			Expect(doc.CopyTo(path)).To(Succeed())
+
+			pkg, err := docx.OpenPackage(path)
+			Expect(err).NotTo(HaveOccurred())
+			comments, exists := pkg.Part(docx.CommentsPart)
+			Expect(exists).To(BeTrue())
+			Expect(string(comments)).To(ContainSubstring(`w:author="Reviewer"`))
+			Expect(string(comments)).To(ContainSubstring(`<w:t>Second line</w:t>`))
+			contentTypes, _ := pkg.Part("[Content_Types].xml")
+			Expect(string(contentTypes)).To(ContainSubstring(`PartName="/word/comments.xml"`))
+			rels, _ := pkg.Part("word/_rels/document.xml.rels")
+			Expect(string(rels)).To(ContainSubstring(`Target="comments.xml"`))
+		})
+	})
 })
@@ -0,0 +1,48 @@
+package templater
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/reporter"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// annotationAuthor is the author of the comments that are attached to the SSP.
+const annotationAuthor = "fedramp-templater"
+
+// annotationText returns the text of the comment for the diff, with one line per value.
+func annotationText(diff reporter.Diff) string {
+	label := diff.Field
+	if diff.Key != "" {
+		label = fmt.Sprintf("%s %s", diff.Field, diff.Key)
+	}
+	lines := []string{}
+	if diff.Change == reporter.Conflict {
+		lines = append(lines, fmt.Sprintf("%s conflicts between the %s and the %s.", label, source.SSP, source.YAML))
+		lines = append(lines, fmt.Sprintf("Base: %s", diff.BaseValue))
+	} else {
+		lines = append(lines, fmt.Sprintf("%s differs between the %s and the %s.", label, source.SSP, source.YAML))
+	}
+	lines = append(lines, fmt.Sprintf("%s: %s", source.YAML, diff.YAMLValue))
+	lines = append(lines, fmt.Sprintf("%s: %s", source.SSP, diff.SSPValue))
+	return strings.Join(lines, "\n")
+}
+
+// AnnotateSSP attaches a Word comment to each table cell of (i.e. modifies) the provided SSP that the reporters found
+// a discrepancy in, showing the values in the YAML and the SSP.
+func AnnotateSSP(s *ssp.Document, reporters []reporter.Reporter) error {
+	for _, rept := range reporters {
+		cellReporter, isCellReporter := rept.(control.CellReporter)
+		if !isCellReporter {
+			continue
+		}
+		err := s.AddComment(cellReporter.Cell(), annotationAuthor, annotationText(rept.Diff()))
+		if err != nil {
+			return err
+		}
+	}
+	return s.UpdateContent()
+}
@@ -0,0 +1,288 @@
+package templater
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// FieldCount is the number of fields of the control tables that would be filled, out of all of them.
+type FieldCount struct {
+	Filled int `json:"filled"`
+	Total  int `json:"total"`
+}
+
+// Percentage returns the percentage of the fields that would be filled, or 0 if there aren't any fields.
+func (f FieldCount) Percentage() float64 {
+	if f.Total == 0 {
+		return 0
+	}
+	return 100 * float64(f.Filled) / float64(f.Total)
+}
+
+func (f *FieldCount) add(field control.FieldCoverage) {
+	f.Total++
+	if field.Filled {
+		f.Filled++
+	}
+}
+
+// FamilyCoverage is the FieldCount of the controls of a family, e.g. `AC`.
+type FamilyCoverage struct {
+	Family string
+	FieldCount
+}
+
+// Coverage is how much of the SSP would be filled from the OpenControls, along with the gaps between the controls of
+// the certification, the justifications of the components and the control tables of the SSP.
+type Coverage struct {
+	// Fields are the fields of the summary and narrative tables of the SSP, in the order of the document.
+	Fields []control.FieldCoverage
+	// HasCertification is whether the data has a certification, which the lists of controls below are for.
+	HasCertification bool
+	// Unjustified are the controls of the certification that none of the components justify.
+	Unjustified []string
+	// Uncertified are the controls that the components justify, but aren't in the certification.
+	Uncertified []string
+	// Unknown are the controls of the SSP tables that are neither in the certification nor justified.
+	Unknown []string
+}
+
+// IsEmpty returns whether there aren't any gaps between the certification, the justifications and the SSP. Fields
+// that would stay empty aren't gaps.
+func (c Coverage) IsEmpty() bool {
+	return len(c.Unjustified) == 0 && len(c.Uncertified) == 0 && len(c.Unknown) == 0
+}
+
+// controlFamily returns the family of the control, e.g. `AC` for `AC-2 (1)`.
+func controlFamily(control string) string {
+	return strings.SplitN(control, "-", 2)[0]
+}
+
+// Families returns the FieldCount of each family of the controls, sorted by family.
+func (c Coverage) Families() []FamilyCoverage {
+	counts := map[string]*FieldCount{}
+	families := []string{}
+	for _, field := range c.Fields {
+		family := controlFamily(field.Control)
+		if _, found := counts[family]; !found {
+			counts[family] = &FieldCount{}
+			families = append(families, family)
+		}
+		counts[family].add(field)
+	}
+	sort.Strings(families)
+	result := []FamilyCoverage{}
+	for _, family := range families {
+		result = append(result, FamilyCoverage{Family: family, FieldCount: *counts[family]})
+	}
+	return result
+}
+
+// Overall returns the FieldCount of all of the controls.
+func (c Coverage) Overall() FieldCount {
+	var count FieldCount
+	for _, field := range c.Fields {
+		count.add(field)
+	}
+	return count
+}
+
+// fieldLabel returns the label of the field for the report, e.g. `Narrative a`.
+func fieldLabel(field control.FieldCoverage) string {
+	if field.Key == "" {
+		return field.Field
+	}
+	return field.Field + " " + field.Key
+}
+
+// emptyFields returns the labels of the fields that would stay empty for each control, and the controls in the order
+// of the document.
+func (c Coverage) emptyFields() (map[string][]string, []string) {
+	empty := map[string][]string{}
+	controls := []string{}
+	for _, field := range c.Fields {
+		if field.Filled {
+			continue
+		}
+		if _, found := empty[field.Control]; !found {
+			controls = append(controls, field.Control)
+		}
+		empty[field.Control] = append(empty[field.Control], fieldLabel(field))
+	}
+	return empty, controls
+}
+
+func formatCount(count FieldCount) string {
+	return fmt.Sprintf("%d/%d (%.1f%%)", count.Filled, count.Total, count.Percentage())
+}
+
+// WriteTextTo writes the coverage as a human-readable report.
+func (c Coverage) WriteTextTo(writer io.Writer) error {
+	lines := []string{}
+	empty, controls := c.emptyFields()
+	lines = append(lines, fmt.Sprintf("Fields that would stay empty: %d", c.Overall().Total-c.Overall().Filled))
+	for _, control := range controls {
+		lines = append(lines, fmt.Sprintf("  %s: %s", control, strings.Join(empty[control], ", ")))
+	}
+
+	lines = append(lines, "Fields that would be filled, by family:")
+	for _, family := range c.Families() {
+		lines = append(lines, fmt.Sprintf("  %s: %s", family.Family, formatCount(family.FieldCount)))
+	}
+	lines = append(lines, fmt.Sprintf("Overall: %s", formatCount(c.Overall())))
+
+	if c.HasCertification {
+		sections := []struct {
+			title    string
+			controls []string
+		}{
+			{"Controls in the certification without a justification", c.Unjustified},
+			{"Controls with a justification that aren't in the certification", c.Uncertified},
+			{"Controls in the SSP that are neither in the certification nor justified", c.Unknown},
+		}
+		for _, section := range sections {
+			lines = append(lines, fmt.Sprintf("%s: %d", section.title, len(section.controls)))
+			if len(section.controls) > 0 {
+				lines = append(lines, "  "+strings.Join(section.controls, ", "))
+			}
+		}
+	}
+
+	_, err := io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+type jsonFieldCount struct {
+	FieldCount
+	Percentage float64 `json:"percentage"`
+}
+
+func newJSONFieldCount(count FieldCount) jsonFieldCount {
+	return jsonFieldCount{FieldCount: count, Percentage: count.Percentage()}
+}
+
+type jsonField struct {
+	Control string `json:"control"`
+	Field   string `json:"field"`
+	Key     string `json:"key,omitempty"`
+	Filled  bool   `json:"filled"`
+}
+
+type jsonCoverage struct {
+	Fields      []jsonField               `json:"fields"`
+	Families    map[string]jsonFieldCount `json:"families"`
+	Overall     jsonFieldCount            `json:"overall"`
+	Unjustified []string                  `json:"unjustified,omitempty"`
+	Uncertified []string                  `json:"uncertified,omitempty"`
+	Unknown     []string                  `json:"unknown,omitempty"`
+}
+
+// WriteJSONTo writes the coverage as JSON, e.g. to track it over time.
+func (c Coverage) WriteJSONTo(writer io.Writer) error {
+	result := jsonCoverage{
+		Fields:      []jsonField{},
+		Families:    map[string]jsonFieldCount{},
+		Overall:     newJSONFieldCount(c.Overall()),
+		Unjustified: c.Unjustified,
+		Uncertified: c.Uncertified,
+		Unknown:     c.Unknown,
+	}
+	for _, field := range c.Fields {
+		result.Fields = append(result.Fields, jsonField(field))
+	}
+	for _, family := range c.Families() {
+		result.Families[family.Family] = newJSONFieldCount(family.FieldCount)
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// fieldCoverage returns the coverage of the fields of the summary and narrative tables of the SSP. Tables that can't
+// be parsed are skipped.
+func fieldCoverage(s *ssp.Document, openControlData opencontrols.Data) ([]control.FieldCoverage, error) {
+	fields := []control.FieldCoverage{}
+	summaryTables, err := s.SummaryTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range summaryTables {
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		tableFields, err := st.Coverage(openControlData)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		fields = append(fields, tableFields...)
+	}
+
+	narrativeTables, err := s.NarrativeTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range narrativeTables {
+		nt := control.NewNarrativeTableWithProfile(table, s.Profile())
+		tableFields, err := nt.Coverage(openControlData)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		fields = append(fields, tableFields...)
+	}
+	return fields, nil
+}
+
+// CheckCoverage finds which fields of the control tables of the SSP would be filled from the data. If the data has a
+// certification, its controls are compared with the justifications of the components and the control tables as well.
+func CheckCoverage(s *ssp.Document, openControlData opencontrols.Data) (Coverage, error) {
+	var coverage Coverage
+	var err error
+	coverage.Fields, err = fieldCoverage(s, openControlData)
+	if err != nil {
+		return coverage, err
+	}
+
+	certified := openControlData.GetCertificationControls()
+	if certified == nil {
+		return coverage, nil
+	}
+	coverage.HasCertification = true
+	isJustified := func(control string) bool {
+		return len(openControlData.GetComponentSatisfies(control)) > 0
+	}
+	for _, control := range certified {
+		if !isJustified(control) {
+			coverage.Unjustified = append(coverage.Unjustified, control)
+		}
+	}
+	for _, control := range openControlData.GetControls() {
+		if !openControlData.InCertification(control) {
+			coverage.Uncertified = append(coverage.Uncertified, control)
+		}
+	}
+
+	found := map[string]bool{}
+	for _, field := range coverage.Fields {
+		control := field.Control
+		if found[control] {
+			continue
+		}
+		found[control] = true
+		if !openControlData.InCertification(control) && !isJustified(control) {
+			coverage.Unknown = append(coverage.Unknown, control)
+		}
+	}
+	return coverage, nil
+}
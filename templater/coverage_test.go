@@ -0,0 +1,94 @@
+package templater_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	. "github.com/opencontrol/fedramp-templater/templater"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckCoverage", func() {
+	It("finds the gaps between the certification and the justifications", func() {
+		s := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+		defer s.Close()
+		openControlData := fixtures.LoadOpenControlFixture()
+		certification, err := opencontrols.LoadCertification(
+			filepath.Join(fixtures.OpenControlFixturePath(), "certifications", "LATO.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		openControlData.SetCertification(certification)
+
+		coverage, err := CheckCoverage(s, openControlData)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(coverage.Unjustified).To(Equal([]string{"AC-6", "CM-2"}))
+		Expect(coverage.Uncertified).To(Equal([]string{"AC-2 (1)"}))
+		// the document has the tables of the AC-2 enhancements as well.
+		Expect(coverage.Unknown).To(Equal([]string{
+			"AC-2 (2)", "AC-2 (3)", "AC-2 (4)", "AC-2 (5)", "AC-2 (7)", "AC-2 (9)", "AC-2 (10)", "AC-2 (12)",
+		}))
+		Expect(coverage.IsEmpty()).To(BeFalse())
+	})
+
+	It("finds the SSP tables of the controls that are neither certified nor justified", func() {
+		s := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+		defer s.Close()
+		openControlData := opencontrols.NewData(opencontrols.Combine())
+		openControlData.SetCertification(opencontrols.NewCertification("Test", []string{"AU-2"}))
+
+		coverage, err := CheckCoverage(s, openControlData)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(coverage.Unjustified).To(Equal([]string{"AU-2"}))
+		Expect(coverage.Uncertified).To(BeEmpty())
+		Expect(coverage.Unknown).To(Equal([]string{"AC-2 (1)"}))
+
+		buf := &bytes.Buffer{}
+		Expect(coverage.WriteTextTo(buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("Controls in the certification without a justification: 1\n  AU-2\n"))
+	})
+
+	It("reports the fields that would be filled", func() {
+		s := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+		defer s.Close()
+
+		coverage, err := CheckCoverage(s, fixtures.LoadOpenControlFixture())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(coverage.HasCertification).To(BeFalse())
+		Expect(coverage.Overall()).To(Equal(FieldCount{Filled: 9, Total: 64}))
+		buf := &bytes.Buffer{}
+		Expect(coverage.WriteTextTo(buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("  AC-2 (10): Responsible Role, Control Origination, Implementation Status, Narrative\n"))
+		Expect(buf.String()).To(ContainSubstring("Overall: 9/64 (14.1%)\n"))
+		Expect(buf.String()).NotTo(ContainSubstring("certification"))
+	})
+
+	It("groups the fields by family", func() {
+		coverage := Coverage{Fields: []control.FieldCoverage{
+			{Control: "AU-2", Field: "Responsible Role", Filled: true},
+			{Control: "AC-2", Field: "Narrative", Key: "a", Filled: true},
+			{Control: "AC-2", Field: "Narrative", Key: "b", Filled: false},
+		}}
+
+		Expect(coverage.Families()).To(Equal([]FamilyCoverage{
+			{Family: "AC", FieldCount: FieldCount{Filled: 1, Total: 2}},
+			{Family: "AU", FieldCount: FieldCount{Filled: 1, Total: 1}},
+		}))
+		Expect(coverage.Overall().Percentage()).To(BeNumerically("~", 66.67, 0.01))
+
+		buf := &bytes.Buffer{}
+		Expect(coverage.WriteJSONTo(buf)).To(Succeed())
+		var result map[string]interface{}
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		Expect(result["families"]).To(HaveKeyWithValue("AC", map[string]interface{}{
+			"filled": 1.0, "total": 2.0, "percentage": 50.0,
+		}))
+	})
+})
@@ -0,0 +1,173 @@
+package templater
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/common/source"
+	"github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// justifications collects the Justification of each control, in the order that the controls are found.
+type justifications struct {
+	controls  []string
+	byControl map[string]*control.Justification
+}
+
+func (j *justifications) get(name string) *control.Justification {
+	justification, exists := j.byControl[name]
+	if !exists {
+		newJustification := control.NewJustification(name)
+		justification = &newJustification
+		j.byControl[name] = justification
+		j.controls = append(j.controls, name)
+	}
+	return justification
+}
+
+func (j *justifications) list() []control.Justification {
+	list := []control.Justification{}
+	for _, name := range j.controls {
+		list = append(list, *j.byControl[name])
+	}
+	return list
+}
+
+func extractSummaryTables(s *ssp.Document, found *justifications) error {
+	tables, err := s.SummaryTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		extracted, err := st.Extract()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		justification := found.get(extracted.Control)
+		justification.ResponsibleRole = extracted.ResponsibleRole
+		justification.Parameters = extracted.Parameters
+		justification.ControlOrigins = extracted.ControlOrigins
+		justification.ImplementationStatuses = extracted.ImplementationStatuses
+	}
+	return nil
+}
+
+func extractNarrativeTables(s *ssp.Document, found *justifications) error {
+	tables, err := s.NarrativeTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		nt := control.NewNarrativeTableWithProfile(table, s.Profile())
+		extracted, err := nt.Extract()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		found.get(extracted.Control).Narratives = extracted.Narratives
+	}
+	return nil
+}
+
+// ExtractSSP reads the information that is filled in the SSP for each control, in the order that the controls are found.
+func ExtractSSP(s *ssp.Document) ([]control.Justification, error) {
+	found := &justifications{byControl: map[string]*control.Justification{}}
+	err := extractSummaryTables(s, found)
+	if err != nil {
+		return nil, err
+	}
+	err = extractNarrativeTables(s, found)
+	if err != nil {
+		return nil, err
+	}
+	return found.list(), nil
+}
+
+// sortedSections converts the map of key to text into sections, sorted by key.
+func sortedSections(texts map[string]string) []opencontrols.Section {
+	keys := []string{}
+	for key := range texts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sections := []opencontrols.Section{}
+	for _, key := range keys {
+		sections = append(sections, opencontrols.Section{Key: key, Text: texts[key]})
+	}
+	return sections
+}
+
+// mostCommonRole returns the responsible role used by the most controls, which becomes the one of the component.
+func mostCommonRole(justifications []control.Justification) string {
+	counts := map[string]int{}
+	role := ""
+	for _, justification := range justifications {
+		if justification.ResponsibleRole == "" {
+			continue
+		}
+		counts[justification.ResponsibleRole]++
+		if role == "" || counts[justification.ResponsibleRole] > counts[role] {
+			role = justification.ResponsibleRole
+		}
+	}
+	return role
+}
+
+// NewComponent converts the justifications found in an SSP to an OpenControl component. Controls without any
+// information are skipped.
+// The responsible role of the component is the most common one, and the controls with another one override it.
+// The checkbox keys are converted to their YAML values using the origin and implementation source mappings.
+func NewComponent(name, key string, justifications []control.Justification) opencontrols.Component {
+	component := opencontrols.NewComponent(name, key)
+	// the roles are filled with the name of the component, e.g. `My System: Admin`.
+	component.ResponsibleRole = strings.TrimPrefix(mostCommonRole(justifications), name+": ")
+
+	originMappings := origin.GetSourceMappings()
+	implementationMappings := implementation.GetSourceMappings()
+	for _, justification := range justifications {
+		if justification.IsEmpty() {
+			continue
+		}
+		satisfies := opencontrols.NewSatisfies(justification.Control)
+		satisfies.Narrative = sortedSections(justification.Narratives)
+		satisfies.Parameters = sortedSections(justification.Parameters)
+		if role := strings.TrimPrefix(justification.ResponsibleRole, name+": "); role != component.ResponsibleRole {
+			satisfies.ResponsibleRole = role
+		}
+
+		origins := []string{}
+		for _, originKey := range justification.ControlOrigins {
+			origins = append(origins, originMappings[originKey][source.YAML])
+		}
+		satisfies.SetControlOrigins(origins)
+
+		statuses := []string{}
+		for _, statusKey := range justification.ImplementationStatuses {
+			statuses = append(statuses, implementationMappings[statusKey][source.YAML])
+		}
+		satisfies.SetImplementationStatuses(statuses)
+
+		component.Satisfies = append(component.Satisfies, satisfies)
+	}
+	return component
+}
+
+// NewCertification creates an OpenControl certification listing the controls found in an SSP.
+func NewCertification(name string, justifications []control.Justification) opencontrols.Certification {
+	controls := []string{}
+	for _, justification := range justifications {
+		controls = append(controls, justification.Control)
+	}
+	return opencontrols.NewCertification(name, controls)
+}
@@ -0,0 +1,33 @@
+package templater
+
+import (
+	"github.com/opencontrol/fedramp-templater/frontmatter"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// FillFrontMatter inserts the metadata of the system into the front-matter tables of the SSP, e.g. the Information
+// System Owner, and returns the number of cells that were filled. The cells are replaced in place rather than as
+// tracked changes, as they only hold the placeholders of the template.
+func FillFrontMatter(s *ssp.Document, system opencontrols.System) (int, error) {
+	count := 0
+	for _, section := range frontmatter.NewSections(system) {
+		tables, err := s.TitledTables(section.Title)
+		if err != nil {
+			return count, err
+		}
+		for idx, table := range tables {
+			fields := section.FieldsFor(idx)
+			if fields == nil {
+				break
+			}
+			ft := frontmatter.NewTable(table)
+			filled, err := ft.Fill(fields)
+			count += filled
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, s.UpdateContent()
+}
@@ -0,0 +1,52 @@
+package templater_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/opencontrol/fedramp-templater/docx"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
+	. "github.com/opencontrol/fedramp-templater/templater"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// loadSSPWithBody creates an SSP with the content of the XML fixture as its body.
+func loadSSPWithBody(name string) *ssp.Document {
+	pkg, err := docx.OpenPackage(fixtures.FixturePath("FedRAMP_ac-2-1_v2.1.docx"))
+	Expect(err).NotTo(HaveOccurred())
+	body, err := ioutil.ReadFile(fixtures.FixturePath(name))
+	Expect(err).NotTo(HaveOccurred())
+	pkg.SetPart(docx.DocumentPart, body)
+	buf := &bytes.Buffer{}
+	Expect(pkg.Write(buf)).To(Succeed())
+
+	s, err := ssp.LoadFrom(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	Expect(err).NotTo(HaveOccurred())
+	return s
+}
+
+var _ = Describe("FillFrontMatter", func() {
+	It("fills in the front-matter tables with the system metadata", func() {
+		s := loadSSPWithBody("front_matter.xml")
+		defer s.Close()
+		system := opencontrols.System{
+			Name:                 "My System",
+			Owner:                opencontrols.Contact{Name: "Jane Doe"},
+			AuthorizingOfficials: []opencontrols.Contact{{Name: "First Official"}, {Name: "Second Official"}},
+		}
+
+		count, err := FillFrontMatter(s, system)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(4))
+		content := s.Content()
+		Expect(content).To(ContainSubstring("<w:t>My System</w:t>"))
+		Expect(content).To(ContainSubstring("<w:t>Jane Doe</w:t>"))
+		Expect(content).To(ContainSubstring("<w:t>First Official</w:t>"))
+		Expect(content).To(ContainSubstring("<w:t>Second Official</w:t>"))
+	})
+})
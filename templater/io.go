@@ -0,0 +1,39 @@
+package templater
+
+import (
+	"io"
+
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/reporter"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// TemplatizeSSPFrom inserts OpenControl data into the SSP read from the reader, which has the provided size in bytes,
+// and writes the filled SSP to the writer. Unlike TemplatizeSSP, the SSP doesn't have to be a file, e.g. it can be held
+// in memory.
+func TemplatizeSSPFrom(r io.ReaderAt, size int64, openControlData opencontrols.Data, w io.Writer) error {
+	s, err := ssp.LoadFrom(r, size)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = TemplatizeSSP(s, openControlData)
+	if err != nil {
+		return err
+	}
+	_, err = s.WriteTo(w)
+	return err
+}
+
+// DiffSSPFrom will find the differences between data in the SSP read from the reader, which has the provided size in
+// bytes, and the OpenControl data.
+func DiffSSPFrom(r io.ReaderAt, size int64, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	s, err := ssp.LoadFrom(r, size)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return DiffSSP(s, openControlData)
+}
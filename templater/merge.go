@@ -0,0 +1,74 @@
+package templater
+
+import (
+	"log"
+
+	"github.com/opencontrol/fedramp-templater/control"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/reporter"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+func mergeSummaryTables(s *ssp.Document, bases control.Justifications,
+	openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	var conflicts []reporter.Reporter
+	tables, err := s.SummaryTables()
+	if err != nil {
+		return conflicts, err
+	}
+	for _, table := range tables {
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		tableConflicts, err := st.Merge(bases, openControlData)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		conflicts = append(conflicts, tableConflicts...)
+	}
+	return conflicts, nil
+}
+
+func mergeNarrativeTables(s *ssp.Document, bases control.Justifications,
+	openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	var conflicts []reporter.Reporter
+	tables, err := s.NarrativeTables()
+	if err != nil {
+		return conflicts, err
+	}
+	for _, table := range tables {
+		nt := control.NewNarrativeTableWithProfile(table, s.Profile())
+		tableConflicts, err := nt.Merge(bases, openControlData)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		conflicts = append(conflicts, tableConflicts...)
+	}
+	return conflicts, nil
+}
+
+// MergeSSP applies the changes made to the OpenControl data since the base SSP was filled to the edited SSP (i.e.
+// modifies it), keeping the changes made to the edited SSP. The fields that were changed differently in both are
+// marked in the edited SSP and returned as conflicts.
+func MergeSSP(base *ssp.Document, edited *ssp.Document, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	justifications, err := ExtractSSP(base)
+	if err != nil {
+		return nil, err
+	}
+	bases := control.NewJustifications(justifications)
+
+	conflicts, err := mergeSummaryTables(edited, bases, openControlData)
+	if err != nil {
+		return conflicts, err
+	}
+	narrativeConflicts, err := mergeNarrativeTables(edited, bases, openControlData)
+	if err != nil {
+		return conflicts, err
+	}
+	conflicts = append(conflicts, narrativeConflicts...)
+	return conflicts, edited.UpdateContent()
+}
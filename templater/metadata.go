@@ -0,0 +1,72 @@
+package templater
+
+import (
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// commitProperty is the custom document property with the commit of the OpenControls that the SSP is filled from.
+const commitProperty = "opencontrol-commit"
+
+// systemProperties returns the custom document properties for the system, skipping the ones that aren't set.
+func systemProperties(system opencontrols.System) map[string]string {
+	properties := map[string]string{}
+	for name, value := range map[string]string{
+		"System Name":  system.Name,
+		"Version":      system.Version,
+		"Date":         system.Date,
+		commitProperty: system.Commit,
+	} {
+		if value != "" {
+			properties[name] = value
+		}
+	}
+	for name, value := range system.Properties {
+		properties[name] = value
+	}
+	return properties
+}
+
+// systemPlaceholders returns the values of the placeholders in the headers and footers of the template, skipping the
+// ones that aren't set.
+func systemPlaceholders(system opencontrols.System) map[string]string {
+	placeholders := map[string]string{}
+	for placeholder, value := range map[string]string{
+		"<Information System Name>": system.Name,
+		"<0.00>":                    system.Version,
+		"<Date>":                    system.Date,
+	} {
+		if value != "" {
+			placeholders[placeholder] = value
+		}
+	}
+	for placeholder, value := range system.Placeholders {
+		placeholders[placeholder] = value
+	}
+	return placeholders
+}
+
+// FillMetadata sets the document properties of the SSP (e.g. the title) from the system metadata, and replaces the
+// placeholders in the headers and footers (e.g. `<Information System Name>`).
+func FillMetadata(s *ssp.Document, system opencontrols.System) error {
+	if system.Name != "" {
+		err := s.SetCoreProperty("dc:title", system.Name)
+		if err != nil {
+			return err
+		}
+	}
+	if system.Version != "" {
+		err := s.SetCoreProperty("cp:version", system.Version)
+		if err != nil {
+			return err
+		}
+	}
+	for name, value := range systemProperties(system) {
+		err := s.SetCustomProperty(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := s.ReplaceHeaderFooterPlaceholders(systemPlaceholders(system))
+	return err
+}
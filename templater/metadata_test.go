@@ -0,0 +1,42 @@
+package templater_test
+
+import (
+	"bytes"
+
+	"github.com/opencontrol/fedramp-templater/docx"
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	. "github.com/opencontrol/fedramp-templater/templater"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FillMetadata", func() {
+	It("sets the document properties and replaces the placeholders in the header", func() {
+		s := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+		defer s.Close()
+		system, err := opencontrols.LoadSystem(fixtures.FixturePath("system.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = FillMetadata(s, system)
+		Expect(err).NotTo(HaveOccurred())
+
+		buf := &bytes.Buffer{}
+		_, err = s.WriteTo(buf)
+		Expect(err).NotTo(HaveOccurred())
+		pkg, err := docx.ReadPackage(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		Expect(err).NotTo(HaveOccurred())
+
+		core, _ := pkg.Part(docx.CorePropertiesPart)
+		Expect(string(core)).To(ContainSubstring("<dc:title>Amazon Web Services</dc:title>"))
+		Expect(string(core)).To(ContainSubstring("<cp:version>1.2</cp:version>"))
+		custom, _ := pkg.Part(docx.CustomPropertiesPart)
+		Expect(string(custom)).To(ContainSubstring(`name="ContentTypeId"`))
+		Expect(string(custom)).To(ContainSubstring(`name="opencontrol-commit"><vt:lpwstr>8c1d2a6</vt:lpwstr>`))
+		Expect(string(custom)).To(ContainSubstring(`name="Agency"><vt:lpwstr>Department of Examples</vt:lpwstr>`))
+		header, _ := pkg.Part("word/header1.xml")
+		Expect(string(header)).To(ContainSubstring("Amazon Web Services System Security Plan"))
+		Expect(string(header)).To(ContainSubstring("Version 1.2 / 2016-08-01"))
+	})
+})
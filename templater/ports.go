@@ -0,0 +1,32 @@
+package templater
+
+import (
+	"errors"
+
+	"github.com/opencontrol/fedramp-templater/opencontrols"
+	"github.com/opencontrol/fedramp-templater/ports"
+	"github.com/opencontrol/fedramp-templater/ssp"
+)
+
+// FillPortsProtocolsServices replaces the rows of the Ports, Protocols and Services table of the SSP with a row for each
+// entry of the components, and returns the number of rows that were added. The table is left as it is when there
+// aren't any.
+func FillPortsProtocolsServices(s *ssp.Document, openControlData opencontrols.Data) (int, error) {
+	entries := openControlData.GetPortsProtocolsServices()
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	tables, err := s.TitledTables(ports.Title)
+	if err != nil {
+		return 0, err
+	}
+	if len(tables) == 0 {
+		return 0, errors.New("unable to find the Ports, Protocols and Services table")
+	}
+	table := ports.NewTable(tables[0])
+	count, err := table.Fill(entries)
+	if err != nil {
+		return count, err
+	}
+	return count, s.UpdateContent()
+}
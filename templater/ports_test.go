@@ -0,0 +1,36 @@
+package templater_test
+
+import (
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	. "github.com/opencontrol/fedramp-templater/templater"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FillPortsProtocolsServices", func() {
+	It("adds the ports, protocols and services of the components to the table", func() {
+		s := loadSSPWithBody("ports_protocols_services.xml")
+		defer s.Close()
+		openControlData := fixtures.LoadOpenControlFixture()
+
+		count, err := FillPortsProtocolsServices(s, openControlData)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+		content := s.Content()
+		Expect(content).To(ContainSubstring("<w:t>HTTPS</w:t>"))
+		Expect(content).To(ContainSubstring("<w:t>Amazon Elastic Compute Cloud</w:t>"))
+		Expect(content).To(ContainSubstring("<w:t>Administrators</w:t>"))
+	})
+
+	It("gives an error when the SSP doesn't have the table", func() {
+		s := loadSSPWithBody("front_matter.xml")
+		defer s.Close()
+		openControlData := fixtures.LoadOpenControlFixture()
+
+		_, err := FillPortsProtocolsServices(s, openControlData)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
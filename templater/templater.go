@@ -2,22 +2,24 @@ package templater
 
 import (
 	"github.com/opencontrol/fedramp-templater/control"
+	docxHelper "github.com/opencontrol/fedramp-templater/docx/helper"
 	"github.com/opencontrol/fedramp-templater/opencontrols"
 	"github.com/opencontrol/fedramp-templater/reporter"
 	"github.com/opencontrol/fedramp-templater/ssp"
 	"log"
 )
 
-func fillSummaryTables(s *ssp.Document, openControlData opencontrols.Data) error {
+func fillSummaryTables(s *ssp.Document, openControlData opencontrols.Data, revision *docxHelper.Revision) error {
 	tables, err := s.SummaryTables()
 	if err != nil {
 		return err
 	}
 	for _, table := range tables {
-		st, err := control.NewSummaryTable(table)
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
 		if err != nil {
 			return err
 		}
+		st.TrackChanges(revision)
 		err = st.Fill(openControlData)
 		if err != nil {
 			return err
@@ -27,13 +29,14 @@ func fillSummaryTables(s *ssp.Document, openControlData opencontrols.Data) error
 	return nil
 }
 
-func fillNarrativeTables(s *ssp.Document, openControlData opencontrols.Data) (err error) {
+func fillNarrativeTables(s *ssp.Document, openControlData opencontrols.Data, revision *docxHelper.Revision) (err error) {
 	tables, err := s.NarrativeTables()
 	if err != nil {
 		return
 	}
 	for _, table := range tables {
-		ct := control.NewNarrativeTable(table)
+		ct := control.NewNarrativeTableWithProfile(table, s.Profile())
+		ct.TrackChanges(revision)
 		err = ct.Fill(openControlData)
 		if err != nil {
 			return
@@ -45,22 +48,38 @@ func fillNarrativeTables(s *ssp.Document, openControlData opencontrols.Data) (er
 
 // TemplatizeSSP inserts OpenControl data into (i.e. modifies) the provided SSP.
 func TemplatizeSSP(s *ssp.Document, openControlData opencontrols.Data) (err error) {
-	fillSummaryTables(s, openControlData)
-	fillNarrativeTables(s, openControlData)
-	s.UpdateContent()
+	return TemplatizeSSPWithTrackedChanges(s, openControlData, nil)
+}
+
+// TemplatizeSSPWithTrackedChanges inserts OpenControl data into (i.e. modifies) the provided SSP, as tracked changes of
+// the revision so that they can be accepted or rejected in Word. Pass a nil `revision` to replace the content in place.
+func TemplatizeSSPWithTrackedChanges(s *ssp.Document, openControlData opencontrols.Data,
+	revision *docxHelper.Revision) (err error) {
+	if revision != nil {
+		s.TrackChanges(revision)
+	}
+	// the tables that can't be filled are left as they are, unless the data is missing with the FailOnMissing policy.
+	err = fillSummaryTables(s, openControlData, revision)
+	if _, missing := err.(opencontrols.MissingDataError); missing {
+		return
+	}
+	err = fillNarrativeTables(s, openControlData, revision)
+	if _, missing := err.(opencontrols.MissingDataError); missing {
+		return
+	}
+	err = s.UpdateContent()
 
 	return
 }
 
-// DiffSSP will find the differences between data in the SSP and the OpenControl data.
-func DiffSSP(s *ssp.Document, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+func diffSummaryTables(s *ssp.Document, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
 	var diffInfo []reporter.Reporter
 	tables, err := s.SummaryTables()
 	if err != nil {
 		return diffInfo, err
 	}
 	for _, table := range tables {
-		st, err := control.NewSummaryTable(table)
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
 		if err != nil {
 			log.Println(err)
 			continue
@@ -74,3 +93,57 @@ func DiffSSP(s *ssp.Document, openControlData opencontrols.Data) ([]reporter.Rep
 	}
 	return diffInfo, nil
 }
+
+func diffNarrativeTables(s *ssp.Document, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	var diffInfo []reporter.Reporter
+	tables, err := s.NarrativeTables()
+	if err != nil {
+		return diffInfo, err
+	}
+	for _, table := range tables {
+		nt := control.NewNarrativeTableWithProfile(table, s.Profile())
+		tableDiffInfo, err := nt.Diff(openControlData)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		diffInfo = append(diffInfo, tableDiffInfo...)
+	}
+	return diffInfo, nil
+}
+
+// SummaryControls returns the controls of the summary tables of the SSP, in the order that they are found. Tables that
+// can't be parsed are skipped.
+func SummaryControls(s *ssp.Document) ([]string, error) {
+	controls := []string{}
+	tables, err := s.SummaryTables()
+	if err != nil {
+		return controls, err
+	}
+	for _, table := range tables {
+		st, err := control.NewSummaryTableWithProfile(table, s.Profile())
+		if err != nil {
+			continue
+		}
+		name, err := st.ControlName()
+		if err != nil {
+			continue
+		}
+		controls = append(controls, name)
+	}
+	return controls, nil
+}
+
+// DiffSSP will find the differences between data in the SSP and the OpenControl data.
+func DiffSSP(s *ssp.Document, openControlData opencontrols.Data) ([]reporter.Reporter, error) {
+	diffInfo, err := diffSummaryTables(s, openControlData)
+	if err != nil {
+		return diffInfo, err
+	}
+	narrativeDiffInfo, err := diffNarrativeTables(s, openControlData)
+	if err != nil {
+		return diffInfo, err
+	}
+	diffInfo = append(diffInfo, narrativeDiffInfo...)
+	return diffInfo, nil
+}
@@ -2,7 +2,12 @@ package templater_test
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
 
+	"github.com/opencontrol/fedramp-templater/common/implementation"
+	"github.com/opencontrol/fedramp-templater/common/origin"
+	"github.com/opencontrol/fedramp-templater/control"
 	"github.com/opencontrol/fedramp-templater/fixtures"
 	. "github.com/opencontrol/fedramp-templater/templater"
 
@@ -72,7 +77,170 @@ var _ = Describe("Templater", func() {
 				"Responsible Role in YAML: \"Amazon Elastic Compute Cloud: AWS Staff\".\n" +
 				"Control: AC-2 (1). " +
 				"Control Origination in YAML: \"shared\". " +
-				"Control Origination in SSP: \"\".\n"))
+				"Control Origination in SSP: \"\".\n" +
+				"Control: AC-2 (1). " +
+				"Implementation Status in YAML: \"partial\". " +
+				"Implementation Status in SSP: \"\".\n"))
+		})
+	})
+
+	Describe("SummaryControls", func() {
+		It("returns the controls of the summary tables in the order of the SSP", func() {
+			s := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+			defer s.Close()
+
+			controls, err := SummaryControls(s)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controls).To(HaveLen(10))
+			Expect(controls[0]).To(Equal("AC-2"))
+			Expect(controls[1]).To(Equal("AC-2 (1)"))
+		})
+	})
+
+	Describe("DiffSSPFrom", func() {
+		It("finds the differences in an SSP held in memory", func() {
+			content, err := ioutil.ReadFile(fixtures.FixturePath("FedRAMP_ac-2-1_v2.1.docx"))
+			Expect(err).NotTo(HaveOccurred())
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			diffInfo, err := DiffSSPFrom(bytes.NewReader(content), int64(len(content)), openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(extractDiffReport(diffInfo)).To(ContainSubstring(
+				"Responsible Role in SSP: \"OpenControl Role Placeholder\""))
+		})
+
+		It("gives an error if the content isn't a Word document", func() {
+			content := []byte("not a docx")
+			_, err := DiffSSPFrom(bytes.NewReader(content), int64(len(content)), fixtures.LoadOpenControlFixture())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ExtractSSP", func() {
+		It("reads the responsible role that is filled in the SSP", func() {
+			s := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer s.Close()
+
+			justifications, err := ExtractSSP(s)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(justifications).To(HaveLen(1))
+			Expect(justifications[0].Control).To(Equal("AC-2 (1)"))
+			Expect(justifications[0].ResponsibleRole).To(Equal("OpenControl Role Placeholder"))
+		})
+
+		It("doesn't read the empty template fields", func() {
+			s := fixtures.LoadSSP("FedRAMP_ac-2_v2.1.docx")
+			defer s.Close()
+
+			justifications, err := ExtractSSP(s)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(justifications).NotTo(BeEmpty())
+			Expect(justifications[0].Control).To(Equal("AC-2"))
+			Expect(justifications[0].Narratives).To(BeEmpty())
+		})
+	})
+
+	Describe("NewComponent", func() {
+		It("uses the most common responsible role, overrides it per control and skips the controls without information", func() {
+			justifications := []control.Justification{
+				control.NewJustification("AC-1"),
+				control.NewJustification("AC-2"),
+				control.NewJustification("AC-3"),
+				control.NewJustification("AC-4"),
+			}
+			justifications[1].ResponsibleRole = "Admin"
+			justifications[2].ResponsibleRole = "Staff"
+			justifications[3].ResponsibleRole = "Staff"
+
+			component := NewComponent("My System", "my_system", justifications)
+
+			Expect(component.ResponsibleRole).To(Equal("Staff"))
+			Expect(component.Satisfies).To(HaveLen(3))
+			Expect(component.Satisfies[0].ControlKey).To(Equal("AC-2"))
+			Expect(component.Satisfies[0].ResponsibleRole).To(Equal("Admin"))
+			Expect(component.Satisfies[1].ResponsibleRole).To(BeEmpty())
+		})
+
+		It("converts the checkboxes to their YAML values", func() {
+			justification := control.NewJustification("AC-2")
+			justification.ControlOrigins = []origin.Key{origin.SharedOrigination}
+			justification.ImplementationStatuses = []implementation.Key{
+				implementation.PlannedImplementation, implementation.PartialImplementation}
+
+			component := NewComponent("My System", "my_system", []control.Justification{justification})
+
+			Expect(component.Satisfies[0].ControlOrigin).To(Equal("shared"))
+			Expect(component.Satisfies[0].ImplementationStatuses).To(Equal([]string{"planned", "partial"}))
+		})
+	})
+
+	Describe("MergeSSP", func() {
+		It("fills in the changes from the YAML when the SSP wasn't edited", func() {
+			base := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer base.Close()
+			edited := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer edited.Close()
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			conflicts, err := MergeSSP(base, edited, openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			content := edited.Content()
+			Expect(content).NotTo(ContainSubstring(`OpenControl Role Placeholder`))
+			Expect(content).To(ContainSubstring(`Justification in narrative form for AC-2 (1)`))
+		})
+
+		It("reports the conflicts when a field was edited in both the SSP and the YAML", func() {
+			base := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer base.Close()
+			edited := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer edited.Close()
+			openControlData := fixtures.LoadOpenControlFixture()
+
+			By("editing the Responsible Role in the SSP")
+			tables, err := edited.SummaryTables()
+			Expect(err).NotTo(HaveOccurred())
+			textNodes, err := tables[0].Search(".//w:t[contains(., 'OpenControl Role Placeholder')]")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(textNodes).To(HaveLen(1))
+			textNodes[0].SetContent("Edited Role")
+
+			conflicts, err := MergeSSP(base, edited, openControlData)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(HaveLen(1))
+			conflict := conflicts[0].Diff()
+			Expect(conflict.Control).To(Equal("AC-2 (1)"))
+			Expect(conflict.Field).To(Equal("Responsible Role"))
+			Expect(conflict.BaseValue).To(Equal("OpenControl Role Placeholder"))
+			Expect(conflict.SSPValue).To(Equal("Edited Role"))
+			Expect(conflict.YAMLValue).To(ContainSubstring("AWS Staff"))
+			Expect(conflict.Change).To(Equal(reporter.Conflict))
+			Expect(edited.Content()).To(ContainSubstring(`SSP Edited Role =======`))
+		})
+	})
+
+	Describe("AnnotateSSP", func() {
+		It("attaches a comment to each cell with a discrepancy", func() {
+			s := fixtures.LoadSSP("FedRAMP_ac-2-1_v2.1.docx")
+			defer s.Close()
+			openControlData := fixtures.LoadOpenControlFixture()
+			diffInfo, err := DiffSSP(s, openControlData)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = AnnotateSSP(s, diffInfo)
+
+			Expect(err).NotTo(HaveOccurred())
+			content := s.Content()
+			// the comments continue after the IDs up to 14 that are used by the bookmarks of the doc.
+			for idx := range diffInfo {
+				Expect(content).To(ContainSubstring(fmt.Sprintf(`<w:commentReference w:id="%d"/>`, 15+idx)))
+			}
 		})
 	})
 })
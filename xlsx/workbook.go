@@ -0,0 +1,243 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// maxSheetNameLength is the longest sheet name that Excel allows, in characters.
+	maxSheetNameLength = 31
+	// headerStyle is the index of the bold cell format in styles.xml.
+	headerStyle = 1
+)
+
+// Workbook is a minimal spreadsheet that can be written as an Office Open XML (.xlsx) file.
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// Sheet is a single worksheet in the workbook.
+type Sheet struct {
+	name string
+	rows []row
+}
+
+type row struct {
+	cells  []interface{}
+	header bool
+}
+
+// NewWorkbook creates an empty Workbook.
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet adds a new, empty worksheet with the provided name to the end of the workbook. Excel requires the names to
+// be unique, so a number is added to the name if another sheet already has it, e.g. `Controls (2)`.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	sheet := &Sheet{name: w.uniqueSheetName(sanitizeSheetName(name))}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// hasSheet returns whether a sheet already has the name, which Excel compares case-insensitively.
+func (w *Workbook) hasSheet(name string) bool {
+	for _, sheet := range w.sheets {
+		if strings.EqualFold(sheet.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSheetName adds a number to the name if another sheet already has it, truncating the name to make room for it.
+func (w *Workbook) uniqueSheetName(name string) string {
+	unique := name
+	for number := 2; w.hasSheet(unique); number++ {
+		suffix := fmt.Sprintf(" (%d)", number)
+		unique = truncate(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	return unique
+}
+
+// AddHeader adds a row of bold cells to the sheet.
+func (s *Sheet) AddHeader(cells ...string) {
+	values := make([]interface{}, len(cells))
+	for idx, cell := range cells {
+		values[idx] = cell
+	}
+	s.rows = append(s.rows, row{cells: values, header: true})
+}
+
+// AddRow adds a row of cells to the sheet. Integers are written as numbers and everything else is written as text.
+func (s *Sheet) AddRow(cells ...interface{}) {
+	s.rows = append(s.rows, row{cells: cells})
+}
+
+// truncate returns the text cut to the length in characters, rather than bytes, so that characters aren't split.
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length])
+}
+
+// sanitizeSheetName removes the characters that Excel doesn't allow in sheet names and truncates the name.
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	return truncate(name, maxSheetNameLength)
+}
+
+// columnName converts the zero-based column index to the spreadsheet column name, e.g. `A`, `Z`, `AA`.
+func columnName(idx int) string {
+	name := ""
+	for idx >= 0 {
+		name = string(rune('A'+idx%26)) + name
+		idx = idx/26 - 1
+	}
+	return name
+}
+
+// escape returns the text escaped for XML, dropping the characters that aren't allowed in XML documents.
+func escape(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, text)
+	buf := &bytes.Buffer{}
+	xml.EscapeText(buf, []byte(text))
+	return buf.String()
+}
+
+func (s *Sheet) xml() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for rowIdx, r := range s.rows {
+		fmt.Fprintf(buf, `<row r="%d">`, rowIdx+1)
+		for colIdx, cell := range r.cells {
+			ref := fmt.Sprintf("%s%d", columnName(colIdx), rowIdx+1)
+			style := ""
+			if r.header {
+				style = fmt.Sprintf(` s="%d"`, headerStyle)
+			}
+			switch value := cell.(type) {
+			case int:
+				fmt.Fprintf(buf, `<c r="%s"%s><v>%d</v></c>`, ref, style, value)
+			default:
+				fmt.Fprintf(buf, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+					ref, style, escape(fmt.Sprint(value)))
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.String()
+}
+
+func (w *Workbook) contentTypesXML() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for idx := range w.sheets {
+		fmt.Fprintf(buf, `<Override PartName="/xl/worksheets/sheet%d.xml" `+
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, idx+1)
+	}
+	buf.WriteString(`</Types>`)
+	return buf.String()
+}
+
+func (w *Workbook) workbookXML() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for idx, sheet := range w.sheets {
+		fmt.Fprintf(buf, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.name), idx+1, idx+1)
+	}
+	buf.WriteString(`</sheets></workbook>`)
+	return buf.String()
+}
+
+func (w *Workbook) workbookRelsXML() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for idx := range w.sheets {
+		fmt.Fprintf(buf, `<Relationship Id="rId%d" `+
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" `+
+			`Target="worksheets/sheet%d.xml"/>`, idx+1, idx+1)
+	}
+	// the styles come after the sheets so that the sheet IDs line up with their index.
+	fmt.Fprintf(buf, `<Relationship Id="rId%d" `+
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" `+
+		`Target="styles.xml"/>`, len(w.sheets)+1)
+	buf.WriteString(`</Relationships>`)
+	return buf.String()
+}
+
+const rootRelsXML = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+	`Target="xl/workbook.xml"/></Relationships>`
+
+// stylesXML contains the default cell format and a bold one for the headers.
+const stylesXML = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// part is a single file within the .xlsx package.
+type part struct {
+	name    string
+	content string
+}
+
+// Write writes the workbook to the writer in the .xlsx format.
+func (w *Workbook) Write(writer io.Writer) error {
+	parts := []part{
+		{"[Content_Types].xml", w.contentTypesXML()},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", w.workbookXML()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRelsXML()},
+		{"xl/styles.xml", stylesXML},
+	}
+	for idx, sheet := range w.sheets {
+		parts = append(parts, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", idx+1), sheet.xml()})
+	}
+
+	zipWriter := zip.NewWriter(writer)
+	for _, p := range parts {
+		partWriter, err := zipWriter.Create(p.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(partWriter, p.content)
+		if err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
@@ -0,0 +1,68 @@
+package xlsx_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/opencontrol/fedramp-templater/fixtures"
+	. "github.com/opencontrol/fedramp-templater/xlsx"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// readParts returns the content of each file in the written workbook.
+func readParts(workbook *Workbook) map[string]string {
+	buf := &bytes.Buffer{}
+	err := workbook.Write(buf)
+	Expect(err).NotTo(HaveOccurred())
+
+	return fixtures.ReadParts(buf.Bytes())
+}
+
+var _ = Describe("Workbook", func() {
+	Describe("Write", func() {
+		It("writes each sheet as a separate part", func() {
+			workbook := NewWorkbook()
+			workbook.AddSheet("First")
+			workbook.AddSheet("Second: with [invalid] chars")
+
+			parts := readParts(workbook)
+
+			Expect(parts).To(HaveKey("[Content_Types].xml"))
+			Expect(parts).To(HaveKey("xl/worksheets/sheet1.xml"))
+			Expect(parts).To(HaveKey("xl/worksheets/sheet2.xml"))
+			Expect(parts["xl/workbook.xml"]).To(ContainSubstring(`<sheet name="First" sheetId="1" r:id="rId1"/>`))
+			Expect(parts["xl/workbook.xml"]).To(ContainSubstring(`<sheet name="Second_ with _invalid_ chars"`))
+		})
+
+		It("truncates the sheet names to whole characters and keeps them unique", func() {
+			workbook := NewWorkbook()
+			long := strings.Repeat("é", 40)
+			workbook.AddSheet(long)
+			workbook.AddSheet(long)
+			workbook.AddSheet("Controls")
+			workbook.AddSheet("controls")
+
+			workbookXML := readParts(workbook)["xl/workbook.xml"]
+
+			Expect(workbookXML).To(ContainSubstring(`<sheet name="` + strings.Repeat("é", 31) + `" sheetId="1"`))
+			Expect(workbookXML).To(ContainSubstring(`<sheet name="` + strings.Repeat("é", 27) + ` (2)" sheetId="2"`))
+			Expect(workbookXML).To(ContainSubstring(`<sheet name="Controls" sheetId="3"`))
+			Expect(workbookXML).To(ContainSubstring(`<sheet name="controls (2)" sheetId="4"`))
+		})
+
+		It("writes the cells as text and numbers", func() {
+			workbook := NewWorkbook()
+			sheet := workbook.AddSheet("Sheet")
+			sheet.AddHeader("Name", "Count")
+			sheet.AddRow("AC & <AU>", 3)
+
+			sheetXML := readParts(workbook)["xl/worksheets/sheet1.xml"]
+
+			Expect(sheetXML).To(ContainSubstring(`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`))
+			Expect(sheetXML).To(ContainSubstring(`<c r="A2" t="inlineStr"><is><t xml:space="preserve">AC &amp; &lt;AU&gt;</t></is></c>`))
+			Expect(sheetXML).To(ContainSubstring(`<c r="B2"><v>3</v></c>`))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package xlsx_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestXlsx(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Xlsx Suite")
+}
@@ -26,3 +26,15 @@ func SearchOne(root xml.Node, xpath string) (xml.Node, error) {
 	}
 	return results[0], nil
 }
+
+// Literal returns the text as an XPath string literal, e.g. to search for a label. XPath 1.0 doesn't have escape
+// sequences, so text with both kinds of quotes is split into a `concat()` of literals.
+func Literal(text string) string {
+	if !strings.Contains(text, "'") {
+		return "'" + text + "'"
+	}
+	if !strings.Contains(text, `"`) {
+		return `"` + text + `"`
+	}
+	return `concat('` + strings.Replace(text, "'", `', "'", '`, -1) + `')`
+}